@@ -5,109 +5,182 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
-
-	"github.com/joho/godotenv"
+	"strings"
 )
 
 // Config represents the application configuration
 type Config struct {
-	Server   ServerConfig   `json:"server"`
-	Qdrant   QdrantConfig   `json:"qdrant"`
-	OpenAI   OpenAIConfig   `json:"openai"`
-	Storage  StorageConfig  `json:"storage"`
-	Chunking ChunkingConfig `json:"chunking"`
-	Search   SearchConfig   `json:"search"`
-	Logging  LoggingConfig  `json:"logging"`
+	Server   ServerConfig   `json:"server" yaml:"server"`
+	OpenAI   OpenAIConfig   `json:"openai" yaml:"openai"`
+	Storage  StorageConfig  `json:"storage" yaml:"storage"`
+	Chunking ChunkingConfig `json:"chunking" yaml:"chunking"`
+	Search   SearchConfig   `json:"search" yaml:"search"`
+	Logging  LoggingConfig  `json:"logging" yaml:"logging"`
+	Secrets  SecretsConfig  `json:"secrets" yaml:"secrets"`
+
+	Notifications NotificationsConfig `json:"notifications" yaml:"notifications"`
+
+	// sources records which file, env var, or secret URI set each field, keyed by
+	// dotted path (e.g. "storage.qdrant.host"). It's populated by LoadConfigWithOptions and
+	// consulted by Inspect; it is unexported so it never round-trips through
+	// json/yaml (de)serialization.
+	sources map[string]Source
 }
 
 // ServerConfig represents server configuration
 type ServerConfig struct {
-	Port         int    `json:"port"`
-	Host         string `json:"host"`
-	ReadTimeout  int    `json:"read_timeout_seconds"`
-	WriteTimeout int    `json:"write_timeout_seconds"`
+	Port         int    `json:"port" yaml:"port"`
+	Host         string `json:"host" yaml:"host"`
+	ReadTimeout  int    `json:"read_timeout_seconds" yaml:"read_timeout_seconds"`
+	WriteTimeout int    `json:"write_timeout_seconds" yaml:"write_timeout_seconds"`
 }
 
-// QdrantConfig represents Qdrant vector database configuration
+// QdrantConfig configures the Qdrant vector database storage backend.
 type QdrantConfig struct {
-	Host           string       `json:"host"`
-	Port           int          `json:"port"`
-	APIKey         string       `json:"-"` // Never serialize API key
-	UseTLS         bool         `json:"use_tls"`
-	Collection     string       `json:"collection"`
-	Docker         DockerConfig `json:"docker"`
-	HealthCheck    bool         `json:"health_check"`
-	RetryAttempts  int          `json:"retry_attempts"`
-	TimeoutSeconds int          `json:"timeout_seconds"`
+	Host           string       `json:"host" yaml:"host"`
+	Port           int          `json:"port" yaml:"port"`
+	APIKey         string       `json:"-" yaml:"api_key,omitempty"` // Never serialize API key
+	UseTLS         bool         `json:"use_tls" yaml:"use_tls"`
+	Collection     string       `json:"collection" yaml:"collection"`
+	Docker         DockerConfig `json:"docker" yaml:"docker"`
+	HealthCheck    bool         `json:"health_check" yaml:"health_check"`
+	RetryAttempts  int          `json:"retry_attempts" yaml:"retry_attempts"`
+	TimeoutSeconds int          `json:"timeout_seconds" yaml:"timeout_seconds"`
 }
 
 // DockerConfig represents Docker-specific configuration
 type DockerConfig struct {
-	Enabled       bool   `json:"enabled"`
-	ContainerName string `json:"container_name"`
-	VolumePath    string `json:"volume_path"`
-	Image         string `json:"image"`
+	Enabled       bool   `json:"enabled" yaml:"enabled"`
+	ContainerName string `json:"container_name" yaml:"container_name"`
+	VolumePath    string `json:"volume_path" yaml:"volume_path"`
+	Image         string `json:"image" yaml:"image"`
 }
 
 // OpenAIConfig represents OpenAI API configuration
 type OpenAIConfig struct {
-	APIKey         string  `json:"-"` // Never serialize API key
-	EmbeddingModel string  `json:"embedding_model"`
-	MaxTokens      int     `json:"max_tokens"`
-	Temperature    float64 `json:"temperature"`
-	RequestTimeout int     `json:"request_timeout_seconds"`
-	RateLimitRPM   int     `json:"rate_limit_rpm"`
+	APIKey         string  `json:"-" yaml:"api_key,omitempty"` // Never serialize API key
+	EmbeddingModel string  `json:"embedding_model" yaml:"embedding_model"`
+	MaxTokens      int     `json:"max_tokens" yaml:"max_tokens"`
+	Temperature    float64 `json:"temperature" yaml:"temperature"`
+	RequestTimeout int     `json:"request_timeout_seconds" yaml:"request_timeout_seconds"`
+	RateLimitRPM   int     `json:"rate_limit_rpm" yaml:"rate_limit_rpm"`
+}
+
+// StorageConfig represents storage configuration. Provider selects which of the
+// typed backend blocks below is active; Config.BuildStorage constructs the matching
+// backend via the storage package's factory registry, and Validate dispatches to that
+// backend's own validator.
+type StorageConfig struct {
+	Provider       string                `json:"provider" yaml:"provider"`
+	RetentionDays  int                   `json:"retention_days" yaml:"retention_days"`
+	BackupEnabled  bool                  `json:"backup_enabled" yaml:"backup_enabled"`
+	BackupInterval int                   `json:"backup_interval_hours" yaml:"backup_interval_hours"`
+	Repositories   map[string]RepoConfig `json:"repositories" yaml:"repositories"`
+
+	Qdrant    QdrantConfig    `json:"qdrant" yaml:"qdrant"`
+	PGVector  PGVectorConfig  `json:"pgvector" yaml:"pgvector"`
+	SQLiteVSS SQLiteVSSConfig `json:"sqlite_vss" yaml:"sqlite_vss"`
+	Weaviate  WeaviateConfig  `json:"weaviate" yaml:"weaviate"`
+	InMemory  InMemoryConfig  `json:"in_memory" yaml:"in_memory"`
 }
 
-// StorageConfig represents storage configuration
-type StorageConfig struct {
-	Provider       string                `json:"provider"`
-	RetentionDays  int                   `json:"retention_days"`
-	BackupEnabled  bool                  `json:"backup_enabled"`
-	BackupInterval int                   `json:"backup_interval_hours"`
-	Repositories   map[string]RepoConfig `json:"repositories"`
+// PGVectorConfig configures the pgvector (PostgreSQL + pgvector extension) storage
+// backend.
+type PGVectorConfig struct {
+	DSN            string `json:"-" yaml:"dsn,omitempty"` // contains credentials, never serialize
+	Schema         string `json:"schema" yaml:"schema"`
+	Table          string `json:"table" yaml:"table"`
+	Dimensions     int    `json:"dimensions" yaml:"dimensions"`
+	TimeoutSeconds int    `json:"timeout_seconds" yaml:"timeout_seconds"`
+}
+
+// SQLiteVSSConfig configures the sqlite-vss (SQLite + vector similarity search
+// extension) storage backend.
+type SQLiteVSSConfig struct {
+	Path       string `json:"path" yaml:"path"`
+	Dimensions int    `json:"dimensions" yaml:"dimensions"`
+}
+
+// WeaviateConfig configures the Weaviate storage backend.
+type WeaviateConfig struct {
+	Host      string `json:"host" yaml:"host"`
+	Scheme    string `json:"scheme" yaml:"scheme"`
+	APIKey    string `json:"-" yaml:"api_key,omitempty"` // Never serialize API key
+	ClassName string `json:"class_name" yaml:"class_name"`
+}
+
+// InMemoryConfig configures the in-memory storage backend, useful for tests and local
+// development where persistence isn't needed.
+type InMemoryConfig struct {
+	MaxVectors int `json:"max_vectors" yaml:"max_vectors"`
 }
 
 // RepoConfig represents repository-specific configuration
 type RepoConfig struct {
-	Enabled         bool     `json:"enabled"`
-	Sensitivity     string   `json:"sensitivity"`
-	ExcludePatterns []string `json:"exclude_patterns"`
-	Tags            []string `json:"tags"`
+	Enabled         bool     `json:"enabled" yaml:"enabled"`
+	Sensitivity     string   `json:"sensitivity" yaml:"sensitivity"`
+	ExcludePatterns []string `json:"exclude_patterns" yaml:"exclude_patterns"`
+	Tags            []string `json:"tags" yaml:"tags"`
 }
 
 // ChunkingConfig represents chunking algorithm configuration
 type ChunkingConfig struct {
-	Strategy              string  `json:"strategy"`
-	MinContentLength      int     `json:"min_content_length"`
-	MaxContentLength      int     `json:"max_content_length"`
-	TodoCompletionTrigger bool    `json:"todo_completion_trigger"`
-	FileChangeThreshold   int     `json:"file_change_threshold"`
-	TimeThresholdMinutes  int     `json:"time_threshold_minutes"`
-	SimilarityThreshold   float64 `json:"similarity_threshold"`
+	Strategy              string  `json:"strategy" yaml:"strategy"`
+	MinContentLength      int     `json:"min_content_length" yaml:"min_content_length"`
+	MaxContentLength      int     `json:"max_content_length" yaml:"max_content_length"`
+	TodoCompletionTrigger bool    `json:"todo_completion_trigger" yaml:"todo_completion_trigger"`
+	FileChangeThreshold   int     `json:"file_change_threshold" yaml:"file_change_threshold"`
+	TimeThresholdMinutes  int     `json:"time_threshold_minutes" yaml:"time_threshold_minutes"`
+	SimilarityThreshold   float64 `json:"similarity_threshold" yaml:"similarity_threshold"`
 }
 
 // SearchConfig represents search behavior configuration
 type SearchConfig struct {
-	DefaultMinRelevance      float64 `json:"default_min_relevance"`
-	RelaxedMinRelevance      float64 `json:"relaxed_min_relevance"`
-	BroadestMinRelevance     float64 `json:"broadest_min_relevance"`
-	EnableProgressiveSearch  bool    `json:"enable_progressive_search"`
-	EnableRepositoryFallback bool    `json:"enable_repository_fallback"`
-	MaxRelatedRepos          int     `json:"max_related_repos"`
+	DefaultMinRelevance      float64 `json:"default_min_relevance" yaml:"default_min_relevance"`
+	RelaxedMinRelevance      float64 `json:"relaxed_min_relevance" yaml:"relaxed_min_relevance"`
+	BroadestMinRelevance     float64 `json:"broadest_min_relevance" yaml:"broadest_min_relevance"`
+	EnableProgressiveSearch  bool    `json:"enable_progressive_search" yaml:"enable_progressive_search"`
+	EnableRepositoryFallback bool    `json:"enable_repository_fallback" yaml:"enable_repository_fallback"`
+	MaxRelatedRepos          int     `json:"max_related_repos" yaml:"max_related_repos"`
 }
 
 // LoggingConfig represents logging configuration
 type LoggingConfig struct {
-	Level      string `json:"level"`
-	Format     string `json:"format"`
-	File       string `json:"file,omitempty"`
-	MaxSize    int    `json:"max_size_mb"`
-	MaxBackups int    `json:"max_backups"`
-	MaxAge     int    `json:"max_age_days"`
+	Level      string `json:"level" yaml:"level"`
+	Format     string `json:"format" yaml:"format"`
+	File       string `json:"file,omitempty" yaml:"file,omitempty"`
+	MaxSize    int    `json:"max_size_mb" yaml:"max_size_mb"`
+	MaxBackups int    `json:"max_backups" yaml:"max_backups"`
+	MaxAge     int    `json:"max_age_days" yaml:"max_age_days"`
+}
+
+// NotificationsConfig lists the sinks that receive memory lifecycle events (chunk
+// created, memory stored, memory decayed, search performed), in the order they should
+// be notified.
+type NotificationsConfig struct {
+	Sinks []NotificationSinkConfig `json:"sinks" yaml:"sinks"`
+}
+
+// NotificationSinkConfig configures a single notification sink. Type selects the
+// delivery mechanism (webhook, nats, kafka, stdout); Events filters which event names
+// the sink receives, e.g. "chunk.created", "memory.decayed", "search.performed".
+type NotificationSinkConfig struct {
+	Type string `json:"type" yaml:"type"`
+
+	// URL is a scheme://host address for webhook and nats sinks, or a
+	// comma-separated list of bare host:port broker addresses for kafka sinks. Unused
+	// for stdout sinks.
+	URL string `json:"url" yaml:"url"`
+
+	AuthToken      string   `json:"-" yaml:"auth_token,omitempty"` // Never serialize auth token
+	Events         []string `json:"events" yaml:"events"`
+	MaxRetries     int      `json:"max_retries" yaml:"max_retries"`
+	BackoffSeconds int      `json:"backoff_seconds" yaml:"backoff_seconds"`
 }
 
 // DefaultConfig returns the default configuration
@@ -119,21 +192,6 @@ func DefaultConfig() *Config {
 			ReadTimeout:  30,
 			WriteTimeout: 30,
 		},
-		Qdrant: QdrantConfig{
-			Host:           "localhost",
-			Port:           6334,
-			UseTLS:         false,
-			Collection:     "claude_memory",
-			HealthCheck:    true,
-			RetryAttempts:  3,
-			TimeoutSeconds: 30,
-			Docker: DockerConfig{
-				Enabled:       true,
-				ContainerName: "claude-memory-qdrant",
-				VolumePath:    "./data/qdrant",
-				Image:         "qdrant/qdrant:latest",
-			},
-		},
 		OpenAI: OpenAIConfig{
 			EmbeddingModel: "text-embedding-ada-002",
 			MaxTokens:      8191,
@@ -147,6 +205,28 @@ func DefaultConfig() *Config {
 			BackupEnabled:  false,
 			BackupInterval: 24,
 			Repositories:   make(map[string]RepoConfig),
+			Qdrant: QdrantConfig{
+				Host:           "localhost",
+				Port:           6334,
+				UseTLS:         false,
+				Collection:     "claude_memory",
+				HealthCheck:    true,
+				RetryAttempts:  3,
+				TimeoutSeconds: 30,
+				Docker: DockerConfig{
+					Enabled:       true,
+					ContainerName: "claude-memory-qdrant",
+					VolumePath:    "./data/qdrant",
+					Image:         "qdrant/qdrant:latest",
+				},
+			},
+			SQLiteVSS: SQLiteVSSConfig{
+				Path:       "./data/memory.db",
+				Dimensions: 1536,
+			},
+			InMemory: InMemoryConfig{
+				MaxVectors: 100000,
+			},
 		},
 		Chunking: ChunkingConfig{
 			Strategy:              "smart",
@@ -172,116 +252,114 @@ func DefaultConfig() *Config {
 			MaxBackups: 3,
 			MaxAge:     30,
 		},
+		Secrets: SecretsConfig{
+			RefreshIntervalSeconds: 300,
+		},
 	}
 }
 
-// LoadConfig loads configuration from environment variables and defaults
+// LoadConfig loads configuration from environment variables and defaults. It is
+// equivalent to LoadConfigWithOptions with no explicit file path: a config file is
+// still picked up if MCP_MEMORY_CONFIG_FILE or one of the standard search paths
+// resolves to one, see LoadConfigWithOptions for the full precedence rules.
 func LoadConfig() (*Config, error) {
-	// Load .env file if it exists
-	if err := godotenv.Load(); err != nil {
-		// Don't fail if .env doesn't exist
-		if !os.IsNotExist(err) {
-			return nil, fmt.Errorf("error loading .env file: %w", err)
-		}
-	}
-
-	config := DefaultConfig()
-
-	// Override with environment variables
-	loadFromEnv(config)
-
-	// Validate configuration
-	if err := config.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid configuration: %w", err)
-	}
-
-	return config, nil
+	return LoadConfigWithOptions(LoadOptions{})
 }
 
-// loadFromEnv loads configuration from environment variables
-func loadFromEnv(config *Config) {
-	loadServerConfig(config)
-	loadQdrantConfig(config)
-	loadStorageAndOtherConfig(config)
-	loadOpenAIConfig(config)
-	loadDecayConfig(config)
-	loadIntelligenceConfig(config)
-	loadPerformanceConfig(config)
+// loadFromEnv loads configuration from environment variables, recording which env var
+// set each field into track (track may be nil, in which case no provenance is kept).
+func loadFromEnv(config *Config, track *sourceTracker) {
+	loadServerConfig(config, track)
+	loadQdrantConfig(config, track)
+	loadStorageAndOtherConfig(config, track)
+	loadOpenAIConfig(config, track)
+	loadDecayConfig(config, track)
+	loadIntelligenceConfig(config, track)
+	loadPerformanceConfig(config, track)
+	loadNotificationsConfig(config, track)
 }
 
 // loadServerConfig loads server configuration from environment
-func loadServerConfig(config *Config) {
+func loadServerConfig(config *Config, track *sourceTracker) {
 	// Server configuration
 	if port := os.Getenv("MCP_MEMORY_PORT"); port != "" {
 		if p, err := strconv.Atoi(port); err == nil {
 			config.Server.Port = p
+			track.set("server.port", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_PORT"})
 		}
 	}
 	if host := os.Getenv("MCP_MEMORY_HOST"); host != "" {
 		config.Server.Host = host
+		track.set("server.host", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_HOST"})
 	}
 
 	// Server timeouts
 	if readTimeout := os.Getenv("MCP_MEMORY_READ_TIMEOUT_SECONDS"); readTimeout != "" {
 		if rt, err := strconv.Atoi(readTimeout); err == nil {
 			config.Server.ReadTimeout = rt
+			track.set("server.read_timeout_seconds", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_READ_TIMEOUT_SECONDS"})
 		}
 	}
 	if writeTimeout := os.Getenv("MCP_MEMORY_WRITE_TIMEOUT_SECONDS"); writeTimeout != "" {
 		if wt, err := strconv.Atoi(writeTimeout); err == nil {
 			config.Server.WriteTimeout = wt
+			track.set("server.write_timeout_seconds", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_WRITE_TIMEOUT_SECONDS"})
 		}
 	}
 }
 
 // loadQdrantConfig loads Qdrant configuration from environment
-func loadQdrantConfig(config *Config) {
-	loadQdrantBasicConfig(config)
-	loadQdrantDockerConfig(config)
+func loadQdrantConfig(config *Config, track *sourceTracker) {
+	loadQdrantBasicConfig(config, track)
+	loadQdrantDockerConfig(config, track)
 }
 
 // loadQdrantBasicConfig loads basic Qdrant settings
-func loadQdrantBasicConfig(config *Config) {
-	loadQdrantConnectionSettings(config)
-	loadQdrantServiceSettings(config)
+func loadQdrantBasicConfig(config *Config, track *sourceTracker) {
+	loadQdrantConnectionSettings(config, track)
+	loadQdrantServiceSettings(config, track)
 }
 
 // loadQdrantConnectionSettings loads host, port, API key, and TLS settings
-func loadQdrantConnectionSettings(config *Config) {
-	config.Qdrant.Host = getStringEnvWithFallback("MCP_MEMORY_QDRANT_HOST", "QDRANT_HOST", config.Qdrant.Host)
-	config.Qdrant.Port = getIntEnvWithFallback("MCP_MEMORY_QDRANT_PORT", "QDRANT_PORT", config.Qdrant.Port)
-	config.Qdrant.APIKey = getStringEnvWithFallback("MCP_MEMORY_QDRANT_API_KEY", "QDRANT_API_KEY", config.Qdrant.APIKey)
-	config.Qdrant.UseTLS = getBoolEnvWithFallback("MCP_MEMORY_QDRANT_USE_TLS", "QDRANT_USE_TLS", config.Qdrant.UseTLS)
-	config.Qdrant.Collection = getStringEnvWithFallback("MCP_MEMORY_QDRANT_COLLECTION", "QDRANT_COLLECTION", config.Qdrant.Collection)
+func loadQdrantConnectionSettings(config *Config, track *sourceTracker) {
+	config.Storage.Qdrant.Host = getStringEnvWithFallback("MCP_MEMORY_QDRANT_HOST", "QDRANT_HOST", config.Storage.Qdrant.Host, "storage.qdrant.host", track)
+	config.Storage.Qdrant.Port = getIntEnvWithFallback("MCP_MEMORY_QDRANT_PORT", "QDRANT_PORT", config.Storage.Qdrant.Port, "storage.qdrant.port", track)
+	config.Storage.Qdrant.APIKey = getStringEnvWithFallback("MCP_MEMORY_QDRANT_API_KEY", "QDRANT_API_KEY", config.Storage.Qdrant.APIKey, "storage.qdrant.api_key", track)
+	config.Storage.Qdrant.UseTLS = getBoolEnvWithFallback("MCP_MEMORY_QDRANT_USE_TLS", "QDRANT_USE_TLS", config.Storage.Qdrant.UseTLS, "storage.qdrant.use_tls", track)
+	config.Storage.Qdrant.Collection = getStringEnvWithFallback("MCP_MEMORY_QDRANT_COLLECTION", "QDRANT_COLLECTION", config.Storage.Qdrant.Collection, "storage.qdrant.collection", track)
 }
 
 // loadQdrantServiceSettings loads service-related settings like health check, retry, and timeout
-func loadQdrantServiceSettings(config *Config) {
-	config.Qdrant.HealthCheck = getBoolEnvWithDefault("MCP_MEMORY_QDRANT_HEALTH_CHECK", config.Qdrant.HealthCheck)
-	config.Qdrant.RetryAttempts = getIntEnvWithDefault("MCP_MEMORY_QDRANT_RETRY_ATTEMPTS", config.Qdrant.RetryAttempts)
-	config.Qdrant.TimeoutSeconds = getIntEnvWithDefault("MCP_MEMORY_QDRANT_TIMEOUT_SECONDS", config.Qdrant.TimeoutSeconds)
+func loadQdrantServiceSettings(config *Config, track *sourceTracker) {
+	config.Storage.Qdrant.HealthCheck = getBoolEnvWithDefault("MCP_MEMORY_QDRANT_HEALTH_CHECK", config.Storage.Qdrant.HealthCheck, "storage.qdrant.health_check", track)
+	config.Storage.Qdrant.RetryAttempts = getIntEnvWithDefault("MCP_MEMORY_QDRANT_RETRY_ATTEMPTS", config.Storage.Qdrant.RetryAttempts, "storage.qdrant.retry_attempts", track)
+	config.Storage.Qdrant.TimeoutSeconds = getIntEnvWithDefault("MCP_MEMORY_QDRANT_TIMEOUT_SECONDS", config.Storage.Qdrant.TimeoutSeconds, "storage.qdrant.timeout_seconds", track)
 }
 
 // getStringEnvWithFallback gets string environment variable with fallback to alternate key
-func getStringEnvWithFallback(primaryKey, fallbackKey, defaultValue string) string {
+func getStringEnvWithFallback(primaryKey, fallbackKey, defaultValue, path string, track *sourceTracker) string {
 	if value := os.Getenv(primaryKey); value != "" {
+		track.set(path, Source{Kind: SourceEnv, Detail: primaryKey})
 		return value
 	}
 	if value := os.Getenv(fallbackKey); value != "" {
+		track.set(path, Source{Kind: SourceEnv, Detail: fallbackKey})
 		return value
 	}
 	return defaultValue
 }
 
 // getIntEnvWithFallback gets integer environment variable with fallback to alternate key
-func getIntEnvWithFallback(primaryKey, fallbackKey string, defaultValue int) int {
+func getIntEnvWithFallback(primaryKey, fallbackKey string, defaultValue int, path string, track *sourceTracker) int {
 	if value := os.Getenv(primaryKey); value != "" {
 		if parsed, err := strconv.Atoi(value); err == nil {
+			track.set(path, Source{Kind: SourceEnv, Detail: primaryKey})
 			return parsed
 		}
 	}
 	if value := os.Getenv(fallbackKey); value != "" {
 		if parsed, err := strconv.Atoi(value); err == nil {
+			track.set(path, Source{Kind: SourceEnv, Detail: fallbackKey})
 			return parsed
 		}
 	}
@@ -289,14 +367,16 @@ func getIntEnvWithFallback(primaryKey, fallbackKey string, defaultValue int) int
 }
 
 // getBoolEnvWithFallback gets boolean environment variable with fallback to alternate key
-func getBoolEnvWithFallback(primaryKey, fallbackKey string, defaultValue bool) bool {
+func getBoolEnvWithFallback(primaryKey, fallbackKey string, defaultValue bool, path string, track *sourceTracker) bool {
 	if value := os.Getenv(primaryKey); value != "" {
 		if parsed, err := strconv.ParseBool(value); err == nil {
+			track.set(path, Source{Kind: SourceEnv, Detail: primaryKey})
 			return parsed
 		}
 	}
 	if value := os.Getenv(fallbackKey); value != "" {
 		if parsed, err := strconv.ParseBool(value); err == nil {
+			track.set(path, Source{Kind: SourceEnv, Detail: fallbackKey})
 			return parsed
 		}
 	}
@@ -304,9 +384,10 @@ func getBoolEnvWithFallback(primaryKey, fallbackKey string, defaultValue bool) b
 }
 
 // getBoolEnvWithDefault gets boolean environment variable with default value
-func getBoolEnvWithDefault(key string, defaultValue bool) bool {
+func getBoolEnvWithDefault(key string, defaultValue bool, path string, track *sourceTracker) bool {
 	if value := os.Getenv(key); value != "" {
 		if parsed, err := strconv.ParseBool(value); err == nil {
+			track.set(path, Source{Kind: SourceEnv, Detail: key})
 			return parsed
 		}
 	}
@@ -314,9 +395,10 @@ func getBoolEnvWithDefault(key string, defaultValue bool) bool {
 }
 
 // getIntEnvWithDefault gets integer environment variable with default value
-func getIntEnvWithDefault(key string, defaultValue int) int {
+func getIntEnvWithDefault(key string, defaultValue int, path string, track *sourceTracker) int {
 	if value := os.Getenv(key); value != "" {
 		if parsed, err := strconv.Atoi(value); err == nil {
+			track.set(path, Source{Kind: SourceEnv, Detail: key})
 			return parsed
 		}
 	}
@@ -324,156 +406,233 @@ func getIntEnvWithDefault(key string, defaultValue int) int {
 }
 
 // loadQdrantDockerConfig loads Docker-related Qdrant settings
-func loadQdrantDockerConfig(config *Config) {
+func loadQdrantDockerConfig(config *Config, track *sourceTracker) {
 	if dockerEnabled := os.Getenv("MCP_MEMORY_QDRANT_DOCKER_ENABLED"); dockerEnabled != "" {
 		if de, err := strconv.ParseBool(dockerEnabled); err == nil {
-			config.Qdrant.Docker.Enabled = de
+			config.Storage.Qdrant.Docker.Enabled = de
+			track.set("storage.qdrant.docker.enabled", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_QDRANT_DOCKER_ENABLED"})
 		}
 	}
 	if containerName := os.Getenv("QDRANT_CONTAINER_NAME"); containerName != "" {
-		config.Qdrant.Docker.ContainerName = containerName
+		config.Storage.Qdrant.Docker.ContainerName = containerName
+		track.set("storage.qdrant.docker.container_name", Source{Kind: SourceEnv, Detail: "QDRANT_CONTAINER_NAME"})
 	}
 	if volumePath := os.Getenv("QDRANT_VOLUME_PATH"); volumePath != "" {
-		config.Qdrant.Docker.VolumePath = volumePath
+		config.Storage.Qdrant.Docker.VolumePath = volumePath
+		track.set("storage.qdrant.docker.volume_path", Source{Kind: SourceEnv, Detail: "QDRANT_VOLUME_PATH"})
 	}
 	if image := os.Getenv("MCP_MEMORY_QDRANT_IMAGE"); image != "" {
-		config.Qdrant.Docker.Image = image
+		config.Storage.Qdrant.Docker.Image = image
+		track.set("storage.qdrant.docker.image", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_QDRANT_IMAGE"})
 	}
 }
 
-func loadStorageAndOtherConfig(config *Config) {
-	loadStorageConfig(config)
-	loadChunkingConfig(config)
-	loadLoggingConfig(config)
+func loadStorageAndOtherConfig(config *Config, track *sourceTracker) {
+	loadStorageConfig(config, track)
+	loadChunkingConfig(config, track)
+	loadLoggingConfig(config, track)
 }
 
 // loadStorageConfig loads storage configuration from environment
-func loadStorageConfig(config *Config) {
+func loadStorageConfig(config *Config, track *sourceTracker) {
 	if provider := os.Getenv("MCP_MEMORY_STORAGE_PROVIDER"); provider != "" {
 		config.Storage.Provider = provider
+		track.set("storage.provider", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_STORAGE_PROVIDER"})
 	}
 	if retention := os.Getenv("RETENTION_DAYS"); retention != "" {
 		if r, err := strconv.Atoi(retention); err == nil {
 			config.Storage.RetentionDays = r
+			track.set("storage.retention_days", Source{Kind: SourceEnv, Detail: "RETENTION_DAYS"})
 		}
 	}
 	if backupEnabled := os.Getenv("MCP_MEMORY_BACKUP_ENABLED"); backupEnabled != "" {
 		if be, err := strconv.ParseBool(backupEnabled); err == nil {
 			config.Storage.BackupEnabled = be
+			track.set("storage.backup_enabled", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_BACKUP_ENABLED"})
 		}
 	}
 	if backupInterval := os.Getenv("MCP_MEMORY_BACKUP_INTERVAL_HOURS"); backupInterval != "" {
 		if bi, err := strconv.Atoi(backupInterval); err == nil {
 			config.Storage.BackupInterval = bi
+			track.set("storage.backup_interval_hours", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_BACKUP_INTERVAL_HOURS"})
 		}
 	}
 }
 
 // loadChunkingConfig loads chunking configuration from environment
-func loadChunkingConfig(config *Config) {
+func loadChunkingConfig(config *Config, track *sourceTracker) {
 	if strategy := os.Getenv("MCP_MEMORY_CHUNKING_STRATEGY"); strategy != "" {
 		config.Chunking.Strategy = strategy
+		track.set("chunking.strategy", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_CHUNKING_STRATEGY"})
 	}
 	if minLength := os.Getenv("MCP_MEMORY_CHUNKING_MIN_LENGTH"); minLength != "" {
 		if ml, err := strconv.Atoi(minLength); err == nil {
 			config.Chunking.MinContentLength = ml
+			track.set("chunking.min_content_length", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_CHUNKING_MIN_LENGTH"})
 		}
 	}
 	if maxLength := os.Getenv("MCP_MEMORY_CHUNKING_MAX_LENGTH"); maxLength != "" {
 		if ml, err := strconv.Atoi(maxLength); err == nil {
 			config.Chunking.MaxContentLength = ml
+			track.set("chunking.max_content_length", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_CHUNKING_MAX_LENGTH"})
 		}
 	}
 	if todoTrigger := os.Getenv("MCP_MEMORY_CHUNKING_TODO_TRIGGER"); todoTrigger != "" {
 		if tt, err := strconv.ParseBool(todoTrigger); err == nil {
 			config.Chunking.TodoCompletionTrigger = tt
+			track.set("chunking.todo_completion_trigger", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_CHUNKING_TODO_TRIGGER"})
 		}
 	}
 }
 
 // loadLoggingConfig loads logging configuration from environment
-func loadLoggingConfig(config *Config) {
+func loadLoggingConfig(config *Config, track *sourceTracker) {
 	if level := os.Getenv("MCP_MEMORY_LOG_LEVEL"); level != "" {
 		config.Logging.Level = level
+		track.set("logging.level", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_LOG_LEVEL"})
 	}
 	if format := os.Getenv("MCP_MEMORY_LOG_FORMAT"); format != "" {
 		config.Logging.Format = format
+		track.set("logging.format", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_LOG_FORMAT"})
 	}
 	if file := os.Getenv("MCP_MEMORY_LOG_FILE"); file != "" {
 		config.Logging.File = file
+		track.set("logging.file", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_LOG_FILE"})
 	}
 	if maxSize := os.Getenv("MCP_MEMORY_LOG_MAX_SIZE_MB"); maxSize != "" {
 		if ms, err := strconv.Atoi(maxSize); err == nil {
 			config.Logging.MaxSize = ms
+			track.set("logging.max_size_mb", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_LOG_MAX_SIZE_MB"})
 		}
 	}
 	if maxBackups := os.Getenv("MCP_MEMORY_LOG_MAX_BACKUPS"); maxBackups != "" {
 		if mb, err := strconv.Atoi(maxBackups); err == nil {
 			config.Logging.MaxBackups = mb
+			track.set("logging.max_backups", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_LOG_MAX_BACKUPS"})
 		}
 	}
 	if maxAge := os.Getenv("MCP_MEMORY_LOG_MAX_AGE_DAYS"); maxAge != "" {
 		if ma, err := strconv.Atoi(maxAge); err == nil {
 			config.Logging.MaxAge = ma
+			track.set("logging.max_age_days", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_LOG_MAX_AGE_DAYS"})
 		}
 	}
 }
 
 // loadOpenAIConfig loads OpenAI configuration from environment
-func loadOpenAIConfig(config *Config) {
+func loadOpenAIConfig(config *Config, track *sourceTracker) {
 	if apiKey := os.Getenv("OPENAI_API_KEY"); apiKey != "" {
 		config.OpenAI.APIKey = apiKey
+		track.set("openai.api_key", Source{Kind: SourceEnv, Detail: "OPENAI_API_KEY"})
 	}
 	if model := os.Getenv("OPENAI_EMBEDDING_MODEL"); model != "" {
 		config.OpenAI.EmbeddingModel = model
+		track.set("openai.embedding_model", Source{Kind: SourceEnv, Detail: "OPENAI_EMBEDDING_MODEL"})
 	}
 	if maxTokens := os.Getenv("MCP_MEMORY_OPENAI_MAX_TOKENS"); maxTokens != "" {
 		if mt, err := strconv.Atoi(maxTokens); err == nil {
 			config.OpenAI.MaxTokens = mt
+			track.set("openai.max_tokens", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_OPENAI_MAX_TOKENS"})
 		}
 	}
 	if temperature := os.Getenv("MCP_MEMORY_OPENAI_TEMPERATURE"); temperature != "" {
 		if temp, err := strconv.ParseFloat(temperature, 64); err == nil {
 			config.OpenAI.Temperature = temp
+			track.set("openai.temperature", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_OPENAI_TEMPERATURE"})
 		}
 	}
 	if requestTimeout := os.Getenv("MCP_MEMORY_OPENAI_REQUEST_TIMEOUT_SECONDS"); requestTimeout != "" {
 		if rt, err := strconv.Atoi(requestTimeout); err == nil {
 			config.OpenAI.RequestTimeout = rt
+			track.set("openai.request_timeout_seconds", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_OPENAI_REQUEST_TIMEOUT_SECONDS"})
 		}
 	}
 	if rateLimitRPM := os.Getenv("MCP_MEMORY_OPENAI_RATE_LIMIT_RPM"); rateLimitRPM != "" {
 		if rl, err := strconv.Atoi(rateLimitRPM); err == nil {
 			config.OpenAI.RateLimitRPM = rl
+			track.set("openai.rate_limit_rpm", Source{Kind: SourceEnv, Detail: "MCP_MEMORY_OPENAI_RATE_LIMIT_RPM"})
 		}
 	}
 }
 
 // loadDecayConfig loads decay configuration from environment
-func loadDecayConfig(_ *Config) {
+func loadDecayConfig(_ *Config, _ *sourceTracker) {
 	// Add decay config loading if needed
 }
 
 // loadIntelligenceConfig loads intelligence configuration from environment
-func loadIntelligenceConfig(_ *Config) {
+func loadIntelligenceConfig(_ *Config, _ *sourceTracker) {
 	// Add intelligence config loading if needed
 }
 
 // loadPerformanceConfig loads performance configuration from environment
-func loadPerformanceConfig(config *Config) {
+func loadPerformanceConfig(_ *Config, _ *sourceTracker) {
 	// Add performance config loading if needed
 }
 
+// loadNotificationsConfig loads notification sinks from environment variables indexed
+// from 0, e.g. MCP_MEMORY_NOTIFICATIONS_0_URL, MCP_MEMORY_NOTIFICATIONS_1_URL. Index i
+// stops being read as soon as neither its TYPE nor URL variable is set, so sinks must
+// be indexed contiguously. A sink already present at index i (e.g. loaded from a config
+// file) has its fields overridden individually rather than replaced wholesale.
+func loadNotificationsConfig(config *Config, track *sourceTracker) {
+	for i := 0; ; i++ {
+		prefix := fmt.Sprintf("MCP_MEMORY_NOTIFICATIONS_%d_", i)
+		sinkType := os.Getenv(prefix + "TYPE")
+		sinkURL := os.Getenv(prefix + "URL")
+		if sinkType == "" && sinkURL == "" {
+			break
+		}
+
+		var sink NotificationSinkConfig
+		if i < len(config.Notifications.Sinks) {
+			sink = config.Notifications.Sinks[i]
+		}
+		path := fmt.Sprintf("notifications.sinks[%d]", i)
+
+		if sinkType != "" {
+			sink.Type = sinkType
+			track.set(path+".type", Source{Kind: SourceEnv, Detail: prefix + "TYPE"})
+		}
+		if sinkURL != "" {
+			sink.URL = sinkURL
+			track.set(path+".url", Source{Kind: SourceEnv, Detail: prefix + "URL"})
+		}
+		if authToken := os.Getenv(prefix + "AUTH_TOKEN"); authToken != "" {
+			sink.AuthToken = authToken
+			track.set(path+".auth_token", Source{Kind: SourceEnv, Detail: prefix + "AUTH_TOKEN"})
+		}
+		if events := os.Getenv(prefix + "EVENTS"); events != "" {
+			sink.Events = strings.Split(events, ",")
+			track.set(path+".events", Source{Kind: SourceEnv, Detail: prefix + "EVENTS"})
+		}
+		if maxRetries := os.Getenv(prefix + "MAX_RETRIES"); maxRetries != "" {
+			if mr, err := strconv.Atoi(maxRetries); err == nil {
+				sink.MaxRetries = mr
+				track.set(path+".max_retries", Source{Kind: SourceEnv, Detail: prefix + "MAX_RETRIES"})
+			}
+		}
+		if backoff := os.Getenv(prefix + "BACKOFF_SECONDS"); backoff != "" {
+			if b, err := strconv.Atoi(backoff); err == nil {
+				sink.BackoffSeconds = b
+				track.set(path+".backoff_seconds", Source{Kind: SourceEnv, Detail: prefix + "BACKOFF_SECONDS"})
+			}
+		}
+
+		if i < len(config.Notifications.Sinks) {
+			config.Notifications.Sinks[i] = sink
+		} else {
+			config.Notifications.Sinks = append(config.Notifications.Sinks, sink)
+		}
+	}
+}
+
 // Validate validates the configuration
 func (c *Config) Validate() error {
 	if err := c.validateServerConfig(); err != nil {
 		return err
 	}
 
-	if err := c.validateQdrantConfig(); err != nil {
-		return err
-	}
-
 	if err := c.validateOpenAIConfig(); err != nil {
 		return err
 	}
@@ -486,6 +645,10 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := c.validateNotificationsConfig(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -500,38 +663,89 @@ func (c *Config) validateServerConfig() error {
 	return nil
 }
 
-// validateQdrantConfig validates Qdrant vector database configuration
-func (c *Config) validateQdrantConfig() error {
-	if c.Qdrant.Host == "" {
+// validateOpenAIConfig validates OpenAI API configuration
+func (c *Config) validateOpenAIConfig() error {
+	if c.OpenAI.APIKey == "" {
+		return errors.New("OpenAI API key is required")
+	}
+	if c.OpenAI.EmbeddingModel == "" {
+		return errors.New("OpenAI embedding model cannot be empty")
+	}
+	return nil
+}
+
+// validateStorageConfig validates the fields shared by every storage provider, then
+// dispatches to the active provider's own validator, rejecting unknown providers.
+func (c *Config) validateStorageConfig() error {
+	if c.Storage.RetentionDays <= 0 {
+		return errors.New("retention days must be positive")
+	}
+
+	switch c.Storage.Provider {
+	case "qdrant":
+		return c.Storage.Qdrant.validate()
+	case "pgvector":
+		return c.Storage.PGVector.validate()
+	case "sqlite-vss":
+		return c.Storage.SQLiteVSS.validate()
+	case "weaviate":
+		return c.Storage.Weaviate.validate()
+	case "in-memory":
+		return nil
+	default:
+		return fmt.Errorf("unknown storage provider: %q", c.Storage.Provider)
+	}
+}
+
+// validate validates Qdrant vector database configuration
+func (c QdrantConfig) validate() error {
+	if c.Host == "" {
 		return errors.New("qdrant host cannot be empty")
 	}
-	if c.Qdrant.Port <= 0 {
+	if c.Port <= 0 {
 		return errors.New("qdrant port must be greater than 0")
 	}
-	if c.Qdrant.Collection == "" {
+	if c.Collection == "" {
 		return errors.New("qdrant collection cannot be empty")
 	}
-	if c.Qdrant.Docker.Enabled && c.Qdrant.Docker.ContainerName == "" {
+	if c.Docker.Enabled && c.Docker.ContainerName == "" {
 		return errors.New("docker container name cannot be empty when docker is enabled")
 	}
 	return nil
 }
 
-// validateOpenAIConfig validates OpenAI API configuration
-func (c *Config) validateOpenAIConfig() error {
-	if c.OpenAI.APIKey == "" {
-		return errors.New("OpenAI API key is required")
+// validate validates pgvector storage configuration
+func (c PGVectorConfig) validate() error {
+	if c.DSN == "" {
+		return errors.New("pgvector dsn cannot be empty")
 	}
-	if c.OpenAI.EmbeddingModel == "" {
-		return errors.New("OpenAI embedding model cannot be empty")
+	if c.Table == "" {
+		return errors.New("pgvector table cannot be empty")
+	}
+	if c.Dimensions <= 0 {
+		return errors.New("pgvector dimensions must be positive")
 	}
 	return nil
 }
 
-// validateStorageConfig validates storage configuration settings
-func (c *Config) validateStorageConfig() error {
-	if c.Storage.RetentionDays <= 0 {
-		return errors.New("retention days must be positive")
+// validate validates sqlite-vss storage configuration
+func (c SQLiteVSSConfig) validate() error {
+	if c.Path == "" {
+		return errors.New("sqlite-vss path cannot be empty")
+	}
+	if c.Dimensions <= 0 {
+		return errors.New("sqlite-vss dimensions must be positive")
+	}
+	return nil
+}
+
+// validate validates Weaviate storage configuration
+func (c WeaviateConfig) validate() error {
+	if c.Host == "" {
+		return errors.New("weaviate host cannot be empty")
+	}
+	if c.ClassName == "" {
+		return errors.New("weaviate class name cannot be empty")
 	}
 	return nil
 }
@@ -550,9 +764,67 @@ func (c *Config) validateChunkingConfig() error {
 	return nil
 }
 
+// validNotificationSinkTypes lists the sink types a notification dispatcher knows how
+// to deliver to.
+var validNotificationSinkTypes = map[string]bool{
+	"webhook": true,
+	"nats":    true,
+	"kafka":   true,
+	"stdout":  true,
+}
+
+// validateNotificationsConfig rejects unknown sink types and, for sinks that deliver
+// over the network, malformed addresses. stdout sinks don't need an address; kafka
+// sinks conventionally use a comma-separated list of bare host:port broker addresses
+// rather than a scheme://host URL, so they're validated separately from the rest.
+func (c *Config) validateNotificationsConfig() error {
+	for i, sink := range c.Notifications.Sinks {
+		if !validNotificationSinkTypes[sink.Type] {
+			return fmt.Errorf("notifications.sinks[%d]: unknown sink type %q", i, sink.Type)
+		}
+
+		switch sink.Type {
+		case "stdout":
+			continue
+		case "kafka":
+			if err := validateKafkaBrokers(sink.URL); err != nil {
+				return fmt.Errorf("notifications.sinks[%d]: %w", i, err)
+			}
+		default:
+			if sink.URL == "" {
+				return fmt.Errorf("notifications.sinks[%d]: url cannot be empty for sink type %q", i, sink.Type)
+			}
+			parsed, err := url.Parse(sink.URL)
+			if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+				return fmt.Errorf("notifications.sinks[%d]: invalid url %q", i, sink.URL)
+			}
+		}
+	}
+	return nil
+}
+
+// validateKafkaBrokers validates a kafka sink's URL field, which holds a
+// comma-separated list of bare host:port broker addresses (e.g.
+// "broker1:9092,broker2:9092") per Kafka convention, rather than a scheme://host URL.
+func validateKafkaBrokers(brokers string) error {
+	if brokers == "" {
+		return errors.New("broker list cannot be empty for sink type \"kafka\"")
+	}
+	for _, broker := range strings.Split(brokers, ",") {
+		broker = strings.TrimSpace(broker)
+		if broker == "" {
+			return errors.New("broker list contains an empty entry")
+		}
+		if _, _, err := net.SplitHostPort(broker); err != nil {
+			return fmt.Errorf("invalid kafka broker address %q: %w", broker, err)
+		}
+	}
+	return nil
+}
+
 // GetDataDir returns the data directory path, creating it if necessary
 func (c *Config) GetDataDir() (string, error) {
-	dataDir := c.Qdrant.Docker.VolumePath
+	dataDir := c.Storage.Qdrant.Docker.VolumePath
 	if dataDir == "" {
 		dataDir = "./data"
 	}
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func validConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.OpenAI.APIKey = "test-key"
+	return cfg
+}
+
+func TestDefaultConfigValidates(t *testing.T) {
+	if err := validConfig().Validate(); err != nil {
+		t.Fatalf("DefaultConfig() (with an OpenAI key set) should validate, got: %v", err)
+	}
+}
+
+func TestValidateStorageConfigDispatchesPerProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "unknown provider rejected",
+			mutate:  func(cfg *Config) { cfg.Storage.Provider = "made-up" },
+			wantErr: "unknown storage provider",
+		},
+		{
+			name: "qdrant dispatch validates qdrant block",
+			mutate: func(cfg *Config) {
+				cfg.Storage.Provider = "qdrant"
+				cfg.Storage.Qdrant.Host = ""
+			},
+			wantErr: "qdrant host cannot be empty",
+		},
+		{
+			name: "pgvector dispatch validates pgvector block",
+			mutate: func(cfg *Config) {
+				cfg.Storage.Provider = "pgvector"
+				cfg.Storage.PGVector.Table = "chunks"
+				cfg.Storage.PGVector.Dimensions = 1536
+				// DSN intentionally left empty
+			},
+			wantErr: "pgvector dsn cannot be empty",
+		},
+		{
+			name: "sqlite-vss dispatch validates sqlite-vss block",
+			mutate: func(cfg *Config) {
+				cfg.Storage.Provider = "sqlite-vss"
+				cfg.Storage.SQLiteVSS.Path = ""
+			},
+			wantErr: "sqlite-vss path cannot be empty",
+		},
+		{
+			name: "weaviate dispatch validates weaviate block",
+			mutate: func(cfg *Config) {
+				cfg.Storage.Provider = "weaviate"
+				cfg.Storage.Weaviate.Host = ""
+			},
+			wantErr: "weaviate host cannot be empty",
+		},
+		{
+			name: "in-memory provider needs no extra fields",
+			mutate: func(cfg *Config) {
+				cfg.Storage.Provider = "in-memory"
+			},
+			wantErr: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestLoadNotificationsConfigFromIndexedEnvVars exercises the
+// MCP_MEMORY_NOTIFICATIONS_<n>_* indexed pattern: sinks are read contiguously
+// starting at index 0 until an index's TYPE and URL are both unset.
+func TestLoadNotificationsConfigFromIndexedEnvVars(t *testing.T) {
+	t.Setenv("MCP_MEMORY_NOTIFICATIONS_0_TYPE", "webhook")
+	t.Setenv("MCP_MEMORY_NOTIFICATIONS_0_URL", "https://example.com/hook")
+	t.Setenv("MCP_MEMORY_NOTIFICATIONS_0_EVENTS", "chunk.created,memory.decayed")
+	t.Setenv("MCP_MEMORY_NOTIFICATIONS_1_TYPE", "stdout")
+
+	cfg := DefaultConfig()
+	track := newSourceTracker()
+	loadNotificationsConfig(cfg, track)
+
+	if len(cfg.Notifications.Sinks) != 2 {
+		t.Fatalf("expected 2 sinks, got %d: %+v", len(cfg.Notifications.Sinks), cfg.Notifications.Sinks)
+	}
+	if cfg.Notifications.Sinks[0].Type != "webhook" || cfg.Notifications.Sinks[0].URL != "https://example.com/hook" {
+		t.Fatalf("unexpected sink 0: %+v", cfg.Notifications.Sinks[0])
+	}
+	wantEvents := []string{"chunk.created", "memory.decayed"}
+	if got := cfg.Notifications.Sinks[0].Events; !reflect.DeepEqual(got, wantEvents) {
+		t.Fatalf("unexpected events: got %v want %v", got, wantEvents)
+	}
+	if cfg.Notifications.Sinks[1].Type != "stdout" {
+		t.Fatalf("unexpected sink 1: %+v", cfg.Notifications.Sinks[1])
+	}
+
+	if track.sources["notifications.sinks[0].url"].Kind != SourceEnv {
+		t.Fatalf("expected SourceEnv provenance for sink 0 url, got %+v", track.sources["notifications.sinks[0].url"])
+	}
+}
+
+func TestValidateNotificationsConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		sinks   []NotificationSinkConfig
+		wantErr string
+	}{
+		{
+			name:  "no sinks is valid",
+			sinks: nil,
+		},
+		{
+			name: "unknown sink type rejected",
+			sinks: []NotificationSinkConfig{
+				{Type: "carrier-pigeon", URL: "https://example.com"},
+			},
+			wantErr: "unknown sink type",
+		},
+		{
+			name: "stdout sink needs no url",
+			sinks: []NotificationSinkConfig{
+				{Type: "stdout"},
+			},
+		},
+		{
+			name: "webhook sink requires a well-formed url",
+			sinks: []NotificationSinkConfig{
+				{Type: "webhook", URL: "not-a-url"},
+			},
+			wantErr: "invalid url",
+		},
+		{
+			name: "webhook sink with a well-formed url is valid",
+			sinks: []NotificationSinkConfig{
+				{Type: "webhook", URL: "https://example.com/hooks/memory"},
+			},
+		},
+		{
+			name: "kafka sink accepts a bare host:port broker list",
+			sinks: []NotificationSinkConfig{
+				{Type: "kafka", URL: "broker1:9092,broker2:9092"},
+			},
+		},
+		{
+			name: "kafka sink rejects a scheme:// url",
+			sinks: []NotificationSinkConfig{
+				{Type: "kafka", URL: "kafka://broker1:9092"},
+			},
+			wantErr: "invalid kafka broker address",
+		},
+		{
+			name: "kafka sink rejects an empty broker list",
+			sinks: []NotificationSinkConfig{
+				{Type: "kafka", URL: ""},
+			},
+			wantErr: "broker list cannot be empty",
+		},
+		{
+			name: "kafka sink rejects a broker missing a port",
+			sinks: []NotificationSinkConfig{
+				{Type: "kafka", URL: "broker1"},
+			},
+			wantErr: "invalid kafka broker address",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.Notifications.Sinks = tt.sinks
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// dottedFieldName returns the dotted-path name under which a Config struct field is
+// addressed in provenance, diffs, and inspection: its json tag, or its yaml tag when
+// the json tag is "-". It also reports whether the field is a secret that must never
+// be shown in the clear (also indicated by json:"-").
+func dottedFieldName(field reflect.StructField) (name string, secret bool) {
+	if jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ","); jsonName != "-" && jsonName != "" {
+		return jsonName, false
+	}
+	if yamlName, _, _ := strings.Cut(field.Tag.Get("yaml"), ","); yamlName != "" {
+		return yamlName, true
+	}
+	return field.Name, true
+}
+
+// walkConfigFields calls visit for every exported, non-struct field reachable from v,
+// with its dotted path and whether it's a secret field. v is typically
+// reflect.ValueOf(*cfg) for read-only access or reflect.ValueOf(cfg).Elem() when visit
+// needs to mutate fields in place. A []T field where T is a struct (e.g.
+// Notifications.Sinks) is walked element by element, with the index appended to the
+// path (e.g. "notifications.sinks[0].url"), rather than treated as one opaque leaf.
+func walkConfigFields(prefix string, v reflect.Value, visit func(path string, secret bool, value reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if structField.PkgPath != "" { // unexported, e.g. Config.sources
+			continue
+		}
+
+		name, secret := dottedFieldName(structField)
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		field := v.Field(i)
+		switch {
+		case field.Kind() == reflect.Struct:
+			walkConfigFields(path, field, visit)
+		case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Struct:
+			for j := 0; j < field.Len(); j++ {
+				walkConfigFields(fmt.Sprintf("%s[%d]", path, j), field.Index(j), visit)
+			}
+		default:
+			visit(path, secret, field)
+		}
+	}
+}
+
+// walkConfigFieldPairs is like walkConfigFields but walks two same-shaped values (e.g.
+// an old and new Config) in lockstep, for diffing. A []T field of structs is compared
+// element by element when old and new have the same length; if the length itself
+// changed, the whole slice is passed to visit as one opaque value instead, since
+// there's no sensible index-for-index pairing across an append/remove.
+func walkConfigFieldPairs(prefix string, oldV, newV reflect.Value, visit func(path string, secret bool, oldValue, newValue reflect.Value)) {
+	t := oldV.Type()
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if structField.PkgPath != "" {
+			continue
+		}
+
+		name, secret := dottedFieldName(structField)
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		oldField := oldV.Field(i)
+		newField := newV.Field(i)
+
+		switch {
+		case oldField.Kind() == reflect.Struct:
+			walkConfigFieldPairs(path, oldField, newField, visit)
+		case oldField.Kind() == reflect.Slice && oldField.Type().Elem().Kind() == reflect.Struct && oldField.Len() == newField.Len():
+			for j := 0; j < oldField.Len(); j++ {
+				walkConfigFieldPairs(fmt.Sprintf("%s[%d]", path, j), oldField.Index(j), newField.Index(j), visit)
+			}
+		default:
+			visit(path, secret, oldField, newField)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// configFileEnvVar points LoadConfigWithOptions at a config file, taking precedence
+// over the standard search paths but not over an explicit LoadOptions.FilePath.
+const configFileEnvVar = "MCP_MEMORY_CONFIG_FILE"
+
+// LoadOptions controls how LoadConfigWithOptions resolves and merges configuration.
+type LoadOptions struct {
+	// FilePath, if set, is loaded verbatim and takes precedence over the
+	// MCP_MEMORY_CONFIG_FILE env var and the standard search paths.
+	FilePath string
+}
+
+// LoadConfigFromFile loads configuration starting from the YAML or JSON file at path,
+// overlays environment variables on top, and validates the merged result. It is a
+// convenience wrapper around LoadConfigWithOptions.
+func LoadConfigFromFile(path string) (*Config, error) {
+	return LoadConfigWithOptions(LoadOptions{FilePath: path})
+}
+
+// LoadConfigWithOptions loads configuration following this precedence, highest first:
+//
+//  1. opts.FilePath, when set explicitly
+//  2. the file named by the MCP_MEMORY_CONFIG_FILE environment variable
+//  3. the standard search paths, in order: ./mcp-memory.yaml,
+//     $XDG_CONFIG_HOME/mcp-memory/config.yaml, /etc/mcp-memory/config.yaml
+//  4. built-in defaults (DefaultConfig)
+//
+// Environment variables handled by loadFromEnv are always applied last, so they
+// override whatever the config file set. Along the way, each field that isn't left at
+// its built-in default has its Source recorded (file path, env var name, or secret
+// URI scheme) for later inspection via Config.Inspect. The merged configuration is
+// validated before being returned.
+func LoadConfigWithOptions(opts LoadOptions) (*Config, error) {
+	// Load .env file if it exists
+	if err := godotenv.Load(); err != nil {
+		// Don't fail if .env doesn't exist
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("error loading .env file: %w", err)
+		}
+	}
+
+	config := DefaultConfig()
+	track := newSourceTracker()
+
+	if path := resolveConfigFilePath(opts.FilePath); path != "" {
+		// before must be an independently-allocated Config, not a shallow copy of
+		// config (`before := *config`): mergeConfigFile's json/yaml Unmarshal reuses
+		// and mutates config's existing maps (e.g. Storage.Repositories) in place
+		// rather than allocating new ones, so a shallow copy's map fields would alias
+		// the very maps being mutated and markFileSources would never see a diff.
+		// DefaultConfig() is called fresh here since config was itself built from it
+		// and nothing has touched config yet at this point in the load sequence.
+		before := DefaultConfig()
+		if err := mergeConfigFile(config, path); err != nil {
+			return nil, fmt.Errorf("error loading config file %q: %w", path, err)
+		}
+		markFileSources(before, config, path, track)
+	}
+
+	// Override with environment variables
+	loadFromEnv(config, track)
+	config.sources = track.sources
+
+	// Resolve any env://, file://, vault://, awssm:// or gcpsm:// secret references
+	if err := config.ResolveSecrets(context.Background()); err != nil {
+		return nil, fmt.Errorf("error resolving secrets: %w", err)
+	}
+
+	// Validate configuration
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+// configSearchPaths lists the default locations checked for a config file, in the
+// order they are tried.
+func configSearchPaths() []string {
+	paths := []string{"./mcp-memory.yaml"}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		paths = append(paths, filepath.Join(xdg, "mcp-memory", "config.yaml"))
+	}
+	return append(paths, "/etc/mcp-memory/config.yaml")
+}
+
+// resolveConfigFilePath picks the config file to load, following the precedence
+// documented on LoadConfigWithOptions. It returns "" if none apply, which is not an
+// error: configuration then falls back to defaults plus environment variables.
+func resolveConfigFilePath(explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+	if envPath := os.Getenv(configFileEnvVar); envPath != "" {
+		return envPath
+	}
+	for _, path := range configSearchPaths() {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// mergeConfigFile reads the file at path and unmarshals it onto config, leaving any
+// field absent from the file at its current (default) value. The format is chosen by
+// file extension: .json is parsed as JSON, anything else as YAML.
+func mergeConfigFile(config *Config, path string) error {
+	data, err := os.ReadFile(path) // #nosec G304 -- path comes from trusted config sources, not request input
+	if err != nil {
+		return err
+	}
+
+	if filepath.Ext(path) == ".json" {
+		return json.Unmarshal(data, config)
+	}
+	return yaml.Unmarshal(data, config)
+}
+
+// markFileSources records SourceFile provenance, under path, for every field that
+// mergeConfigFile changed relative to the pristine defaults in before.
+func markFileSources(before, after *Config, path string, track *sourceTracker) {
+	walkConfigFieldPairs("", reflect.ValueOf(*before), reflect.ValueOf(*after), func(fieldPath string, _ bool, oldValue, newValue reflect.Value) {
+		if !reflect.DeepEqual(oldValue.Interface(), newValue.Interface()) {
+			track.set(fieldPath, Source{Kind: SourceFile, Detail: path})
+			// A []T of structs whose length changed arrives here whole; Inspect reports
+			// its elements field by field, so record each element's fields too.
+			if newValue.Kind() == reflect.Slice && newValue.Type().Elem().Kind() == reflect.Struct {
+				for j := 0; j < newValue.Len(); j++ {
+					walkConfigFields(fmt.Sprintf("%s[%d]", fieldPath, j), newValue.Index(j), func(elemPath string, _ bool, _ reflect.Value) {
+						track.set(elemPath, Source{Kind: SourceFile, Detail: path})
+					})
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeYAMLConfig(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("writing config file: %v", err)
+	}
+	return path
+}
+
+// TestLoadConfigWithOptions_FileSourceProvenance guards against the shallow-copy bug
+// where markFileSources compared config.Storage.Repositories against itself (the same
+// map mergeConfigFile had just mutated in place), silently mis-attributing
+// file-sourced fields as SourceDefault.
+func TestLoadConfigWithOptions_FileSourceProvenance(t *testing.T) {
+	path := writeYAMLConfig(t, `
+openai:
+  embedding_model: file-model
+storage:
+  repositories:
+    myrepo:
+      enabled: true
+      sensitivity: high
+`)
+	t.Setenv("OPENAI_API_KEY", "test-key")
+
+	cfg, err := LoadConfigWithOptions(LoadOptions{FilePath: path})
+	if err != nil {
+		t.Fatalf("LoadConfigWithOptions: %v", err)
+	}
+
+	if cfg.OpenAI.EmbeddingModel != "file-model" {
+		t.Fatalf("expected file value to apply, got %q", cfg.OpenAI.EmbeddingModel)
+	}
+	if _, ok := cfg.Storage.Repositories["myrepo"]; !ok {
+		t.Fatalf("expected myrepo repository to be loaded from file, got %+v", cfg.Storage.Repositories)
+	}
+
+	report, err := cfg.Inspect()
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+
+	sourceOf := map[string]SourceKind{}
+	for _, f := range report.Fields {
+		sourceOf[f.Path] = f.SourceKind
+	}
+
+	if sourceOf["storage.repositories"] != SourceFile {
+		t.Errorf("storage.repositories: expected SourceFile, got %v", sourceOf["storage.repositories"])
+	}
+	if sourceOf["openai.embedding_model"] != SourceFile {
+		t.Errorf("openai.embedding_model: expected SourceFile, got %v", sourceOf["openai.embedding_model"])
+	}
+}
+
+func TestLoadConfigWithOptions_EnvOverridesFile(t *testing.T) {
+	path := writeYAMLConfig(t, "openai:\n  embedding_model: file-model\n")
+
+	t.Setenv("OPENAI_API_KEY", "test-key")
+	t.Setenv("OPENAI_EMBEDDING_MODEL", "env-model")
+
+	cfg, err := LoadConfigWithOptions(LoadOptions{FilePath: path})
+	if err != nil {
+		t.Fatalf("LoadConfigWithOptions: %v", err)
+	}
+	if cfg.OpenAI.EmbeddingModel != "env-model" {
+		t.Fatalf("expected env var to win over file, got %q", cfg.OpenAI.EmbeddingModel)
+	}
+
+	report, err := cfg.Inspect()
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	for _, f := range report.Fields {
+		if f.Path == "openai.embedding_model" && f.SourceKind != SourceEnv {
+			t.Errorf("expected SourceEnv, got %v", f.SourceKind)
+		}
+	}
+}
+
+func TestLoadConfigWithOptions_FieldsLeftAtDefaultReportSourceDefault(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "test-key")
+	// Keep the $XDG_CONFIG_HOME search path from accidentally picking up a real file.
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cfg, err := LoadConfigWithOptions(LoadOptions{})
+	if err != nil {
+		t.Fatalf("LoadConfigWithOptions: %v", err)
+	}
+
+	report, err := cfg.Inspect()
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	for _, f := range report.Fields {
+		if f.Path == "storage.provider" && f.SourceKind != SourceDefault {
+			t.Errorf("expected SourceDefault for storage.provider, got %v", f.SourceKind)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+)
+
+// SourceKind identifies where a config field's effective value came from.
+type SourceKind string
+
+const (
+	SourceDefault SourceKind = "default"
+	SourceFile    SourceKind = "file"
+	SourceEnv     SourceKind = "env"
+	SourceSecret  SourceKind = "secret"
+)
+
+// Source records where a single config field's value was set from: a config file
+// path, an env var name, or a secret URI scheme. Detail is empty for SourceDefault.
+type Source struct {
+	Kind   SourceKind `json:"kind"`
+	Detail string     `json:"detail,omitempty"`
+}
+
+// sourceTracker accumulates, during a single LoadConfigWithOptions call, which Source
+// set each dotted field path (e.g. "storage.qdrant.host"). Every method is nil-safe so
+// callers that don't need provenance can pass around a nil *sourceTracker.
+type sourceTracker struct {
+	sources map[string]Source
+}
+
+func newSourceTracker() *sourceTracker {
+	return &sourceTracker{sources: make(map[string]Source)}
+}
+
+func (t *sourceTracker) set(path string, source Source) {
+	if t == nil {
+		return
+	}
+	t.sources[path] = source
+}
+
+// FieldReport describes one field of the effective configuration returned by
+// Config.Inspect: its value (redacted if it's a secret) and where that value came
+// from.
+type FieldReport struct {
+	Path         string     `json:"path"`
+	Value        any        `json:"value,omitempty"`
+	Redacted     bool       `json:"redacted,omitempty"`
+	SourceKind   SourceKind `json:"source_kind"`
+	SourceDetail string     `json:"source_detail,omitempty"`
+}
+
+// InspectReport is the fully-resolved effective configuration, field by field, with
+// provenance and secret redaction applied. It's what the inspect MCP tool/HTTP
+// endpoint serializes as JSON.
+type InspectReport struct {
+	Fields []FieldReport `json:"fields"`
+}
+
+// Inspect returns the fully-resolved effective configuration annotated with, per
+// field, where its value came from (default, file, env var, or secret URI), and with
+// every secret field (API keys, tokens, anything tagged json:"-") redacted to a
+// SHA-256 fingerprint plus length rather than shown in the clear. It gives operators a
+// single command to answer "why is my retention 30 days" without grepping env vars,
+// and the redacted result is safe to paste into a bug report.
+func (c *Config) Inspect() (InspectReport, error) {
+	var fields []FieldReport
+
+	walkConfigFields("", reflect.ValueOf(*c), func(path string, secret bool, value reflect.Value) {
+		report := FieldReport{Path: path}
+
+		if source, ok := c.sources[path]; ok {
+			report.SourceKind = source.Kind
+			report.SourceDetail = source.Detail
+		} else {
+			report.SourceKind = SourceDefault
+		}
+
+		if secret {
+			report.Redacted = true
+			report.Value = redactSecret(value.Interface())
+		} else {
+			report.Value = value.Interface()
+		}
+
+		fields = append(fields, report)
+	})
+
+	return InspectReport{Fields: fields}, nil
+}
+
+// redactSecret turns a secret field's value into a fingerprint safe to log or paste
+// into a bug report: a SHA-256 hash of the value plus its length, never the value
+// itself.
+func redactSecret(value any) string {
+	str, ok := value.(string)
+	if !ok || str == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(str))
+	return fmt.Sprintf("sha256:%s (len=%d)", hex.EncodeToString(sum[:]), len(str))
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestInspectRedactsSecretFields(t *testing.T) {
+	cfg := validConfig()
+	cfg.OpenAI.APIKey = "super-secret-value"
+
+	report, err := cfg.Inspect()
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+
+	for _, f := range report.Fields {
+		if f.Path != "openai.api_key" {
+			continue
+		}
+		if !f.Redacted {
+			t.Fatal("expected openai.api_key to be marked redacted")
+		}
+		redacted, ok := f.Value.(string)
+		if !ok || strings.Contains(redacted, "super-secret-value") {
+			t.Fatalf("expected a redacted fingerprint, got %v", f.Value)
+		}
+		if !strings.HasPrefix(redacted, "sha256:") {
+			t.Fatalf("expected sha256 fingerprint format, got %q", redacted)
+		}
+		return
+	}
+	t.Fatal("openai.api_key not present in Inspect report")
+}
+
+// TestInspectRedactsSliceElementSecretFields guards against walkConfigFields treating a
+// []T field of structs (Notifications.Sinks) as one opaque leaf, which previously let
+// NotificationSinkConfig.AuthToken through Inspect() in the clear instead of redacted.
+func TestInspectRedactsSliceElementSecretFields(t *testing.T) {
+	cfg := validConfig()
+	cfg.Notifications.Sinks = []NotificationSinkConfig{
+		{Type: "webhook", URL: "https://example.com", AuthToken: "webhook-secret"},
+	}
+
+	report, err := cfg.Inspect()
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+
+	for _, f := range report.Fields {
+		if f.Path != "notifications.sinks[0].auth_token" {
+			continue
+		}
+		if !f.Redacted {
+			t.Fatal("expected notifications.sinks[0].auth_token to be marked redacted")
+		}
+		redacted, ok := f.Value.(string)
+		if !ok || strings.Contains(redacted, "webhook-secret") {
+			t.Fatalf("expected a redacted fingerprint, got %v", f.Value)
+		}
+		return
+	}
+	t.Fatal("notifications.sinks[0].auth_token not present in Inspect report")
+}
+
+func TestSecretFieldsNeverSerialize(t *testing.T) {
+	cfg := validConfig()
+	cfg.OpenAI.APIKey = "super-secret-value"
+	cfg.Storage.Qdrant.APIKey = "qdrant-secret"
+	cfg.Notifications.Sinks = []NotificationSinkConfig{
+		{Type: "webhook", URL: "https://example.com", AuthToken: "webhook-secret"},
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+
+	for _, secret := range []string{"super-secret-value", "qdrant-secret", "webhook-secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Fatalf("serialized config leaks secret %q", secret)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadableFields lists the dotted field paths (matching their json tag, nested with
+// ".") that may change on a hot-reload without requiring a process restart. Anything
+// not listed here still appears in ConfigChange.Diff, but with RestartRequired set, so
+// subscribers like the logger or search thresholds know what they can safely apply
+// live versus what only takes effect after a restart.
+var reloadableFields = map[string]bool{
+	"logging.level":                     true,
+	"logging.format":                    true,
+	"search.default_min_relevance":      true,
+	"search.relaxed_min_relevance":      true,
+	"search.broadest_min_relevance":     true,
+	"search.enable_progressive_search":  true,
+	"search.enable_repository_fallback": true,
+	"search.max_related_repos":          true,
+	"chunking.similarity_threshold":     true,
+	"chunking.time_threshold_minutes":   true,
+	"chunking.file_change_threshold":    true,
+	"storage.retention_days":            true,
+}
+
+// FieldChange describes one field that differs between the old and new Config in a
+// ConfigChange.
+type FieldChange struct {
+	Path            string `json:"path"`
+	Old             any    `json:"old"`
+	New             any    `json:"new"`
+	RestartRequired bool   `json:"restart_required"`
+}
+
+// ConfigChange is delivered to Manager subscribers whenever a reload swaps in a new
+// Config.
+type ConfigChange struct {
+	Old  *Config
+	New  *Config
+	Diff []FieldChange
+}
+
+// Manager wraps a *Config and keeps it safely hot-reloadable: it watches the config
+// file with fsnotify, reacts to SIGHUP, and only swaps in a reloaded config once it
+// passes Validate.
+type Manager struct {
+	path string
+
+	mu      sync.RWMutex
+	current *Config
+
+	subMu       sync.Mutex
+	subscribers []chan ConfigChange
+
+	watcher *fsnotify.Watcher
+	sighup  chan os.Signal
+	done    chan struct{}
+}
+
+// NewManager creates a Manager around an already-loaded config. If path is non-empty
+// it is watched for changes; path is also what subsequent reloads re-read via
+// LoadConfigWithOptions.
+func NewManager(initial *Config, path string) (*Manager, error) {
+	m := &Manager{
+		path:    path,
+		current: initial,
+		sighup:  make(chan os.Signal, 1),
+		done:    make(chan struct{}),
+	}
+
+	if path != "" {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return nil, fmt.Errorf("creating config file watcher: %w", err)
+		}
+		if err := watcher.Add(path); err != nil {
+			_ = watcher.Close()
+			return nil, fmt.Errorf("watching config file %q: %w", path, err)
+		}
+		m.watcher = watcher
+	}
+
+	signal.Notify(m.sighup, syscall.SIGHUP)
+
+	go m.run()
+
+	return m, nil
+}
+
+// deepCopyConfig returns a copy of cfg that shares no mutable state (maps, slices)
+// with it, so a caller can freely modify the result without corrupting cfg. A plain
+// `snapshot := *cfg` isn't enough: Storage.Repositories, Notifications.Sinks, and the
+// unexported sources map would still alias cfg's own maps/slices.
+func deepCopyConfig(cfg *Config) *Config {
+	snapshot := *cfg
+
+	if cfg.Storage.Repositories != nil {
+		snapshot.Storage.Repositories = make(map[string]RepoConfig, len(cfg.Storage.Repositories))
+		for repo, repoCfg := range cfg.Storage.Repositories {
+			snapshot.Storage.Repositories[repo] = copyRepoConfig(repoCfg)
+		}
+	}
+
+	if cfg.Notifications.Sinks != nil {
+		snapshot.Notifications.Sinks = make([]NotificationSinkConfig, len(cfg.Notifications.Sinks))
+		for i, sink := range cfg.Notifications.Sinks {
+			snapshot.Notifications.Sinks[i] = copyNotificationSinkConfig(sink)
+		}
+	}
+
+	if cfg.sources != nil {
+		snapshot.sources = make(map[string]Source, len(cfg.sources))
+		for path, source := range cfg.sources {
+			snapshot.sources[path] = source
+		}
+	}
+
+	return &snapshot
+}
+
+func copyRepoConfig(rc RepoConfig) RepoConfig {
+	if rc.ExcludePatterns != nil {
+		rc.ExcludePatterns = append([]string(nil), rc.ExcludePatterns...)
+	}
+	if rc.Tags != nil {
+		rc.Tags = append([]string(nil), rc.Tags...)
+	}
+	return rc
+}
+
+func copyNotificationSinkConfig(sink NotificationSinkConfig) NotificationSinkConfig {
+	if sink.Events != nil {
+		sink.Events = append([]string(nil), sink.Events...)
+	}
+	return sink
+}
+
+// Current returns an immutable snapshot of the active configuration. The returned
+// Config is a deep copy: mutating a map or slice field on it (e.g.
+// Storage.Repositories) never reaches back into the Manager's live config.
+func (m *Manager) Current() *Config {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return deepCopyConfig(m.current)
+}
+
+// Subscribe returns a channel that receives a ConfigChange when a reload swaps in a
+// new config. The channel buffers one change; a change sent while that buffer is still
+// full is dropped for this subscriber. The channel is closed when the Manager is
+// closed.
+func (m *Manager) Subscribe() <-chan ConfigChange {
+	ch := make(chan ConfigChange, 1)
+	m.subMu.Lock()
+	m.subscribers = append(m.subscribers, ch)
+	m.subMu.Unlock()
+	return ch
+}
+
+// Close stops watching for changes and closes every subscriber channel.
+func (m *Manager) Close() error {
+	close(m.done)
+	signal.Stop(m.sighup)
+
+	var err error
+	if m.watcher != nil {
+		err = m.watcher.Close()
+	}
+
+	m.subMu.Lock()
+	for _, ch := range m.subscribers {
+		close(ch)
+	}
+	m.subscribers = nil
+	m.subMu.Unlock()
+
+	return err
+}
+
+// run watches for SIGHUP and config file events until the Manager is closed.
+func (m *Manager) run() {
+	var events <-chan fsnotify.Event
+	if m.watcher != nil {
+		events = m.watcher.Events
+	}
+
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-m.sighup:
+			m.reload()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+				m.reload()
+			}
+		}
+	}
+}
+
+// reload re-runs LoadConfigWithOptions against m.path and only swaps it in (and
+// notifies subscribers) if the result passes Validate. If loading or validation fails,
+// the error is dropped: nothing is logged, subscribers are not notified, and the
+// Manager keeps serving the last good config.
+func (m *Manager) reload() {
+	next, err := LoadConfigWithOptions(LoadOptions{FilePath: m.path})
+	if err != nil {
+		return
+	}
+
+	m.mu.Lock()
+	old := m.current
+	m.current = next
+	m.mu.Unlock()
+
+	m.notify(ConfigChange{
+		Old:  old,
+		New:  next,
+		Diff: diffConfig(old, next),
+	})
+}
+
+func (m *Manager) notify(change ConfigChange) {
+	m.subMu.Lock()
+	defer m.subMu.Unlock()
+	for _, ch := range m.subscribers {
+		select {
+		case ch <- change:
+		default:
+			// Don't block reload on a slow subscriber; they can always call Current().
+		}
+	}
+}
+
+// diffConfig compares oldCfg and newCfg field by field and reports every field that
+// changed, dotted-path style (e.g. "storage.qdrant.host"). Secret fields (API keys,
+// tokens) are still reported, since subscribers like a storage client need to know a
+// credential rotated so they can reconnect — but Old and New are left nil rather than
+// carrying the plaintext value, so a subscriber that only logs or serializes
+// ConfigChange.Diff can't leak it. The same masking applies to a slice of structs
+// reported whole because its length changed, when its element type has a secret field.
+func diffConfig(oldCfg, newCfg *Config) []FieldChange {
+	var changes []FieldChange
+
+	walkConfigFieldPairs("", reflect.ValueOf(*oldCfg), reflect.ValueOf(*newCfg), func(path string, secret bool, oldField, newField reflect.Value) {
+		if reflect.DeepEqual(oldField.Interface(), newField.Interface()) {
+			return
+		}
+
+		change := FieldChange{
+			Path:            path,
+			Old:             oldField.Interface(),
+			New:             newField.Interface(),
+			RestartRequired: !reloadableFields[path],
+		}
+		// A []T of structs whose length changed arrives here whole (see
+		// walkConfigFieldPairs); mask it too if T has a secret field, since its elements
+		// would otherwise carry that field in the clear.
+		if !secret && oldField.Kind() == reflect.Slice && oldField.Type().Elem().Kind() == reflect.Struct {
+			elemType := oldField.Type().Elem()
+			for i := 0; i < elemType.NumField() && !secret; i++ {
+				if elemField := elemType.Field(i); elemField.PkgPath == "" {
+					_, secret = dottedFieldName(elemField)
+				}
+			}
+		}
+		if secret {
+			change.Old = nil
+			change.New = nil
+		}
+		changes = append(changes, change)
+	})
+
+	return changes
+}
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeManagerConfigFile(t *testing.T, path, yamlBody string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(yamlBody), 0o600); err != nil {
+		t.Fatalf("writing config file: %v", err)
+	}
+}
+
+func TestManagerReload_SwapsInValidConfig(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "test-key")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeManagerConfigFile(t, path, "logging:\n  level: info\n")
+
+	initial, err := LoadConfigWithOptions(LoadOptions{FilePath: path})
+	if err != nil {
+		t.Fatalf("initial load: %v", err)
+	}
+
+	mgr, err := NewManager(initial, path)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	defer func() { _ = mgr.Close() }()
+
+	sub := mgr.Subscribe()
+
+	writeManagerConfigFile(t, path, "logging:\n  level: debug\n")
+	mgr.reload()
+
+	select {
+	case change := <-sub:
+		if change.New.Logging.Level != "debug" {
+			t.Fatalf("expected reloaded level debug, got %q", change.New.Logging.Level)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for ConfigChange notification")
+	}
+
+	if mgr.Current().Logging.Level != "debug" {
+		t.Fatalf("expected Current() to reflect the reload, got %q", mgr.Current().Logging.Level)
+	}
+}
+
+func TestManagerReload_KeepsLastGoodConfigOnInvalidEdit(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "test-key")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeManagerConfigFile(t, path, "server:\n  port: 8080\n")
+
+	initial, err := LoadConfigWithOptions(LoadOptions{FilePath: path})
+	if err != nil {
+		t.Fatalf("initial load: %v", err)
+	}
+
+	mgr, err := NewManager(initial, path)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	defer func() { _ = mgr.Close() }()
+
+	// An out-of-range port fails Validate, so the bad edit must not be swapped in.
+	writeManagerConfigFile(t, path, "server:\n  port: 99999\n")
+	mgr.reload()
+
+	if mgr.Current().Server.Port != 8080 {
+		t.Fatalf("expected last good port 8080 to be kept, got %d", mgr.Current().Server.Port)
+	}
+}
+
+func TestManagerCurrent_SnapshotIsDeepCopy(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "test-key")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeManagerConfigFile(t, path, "logging:\n  level: info\n")
+
+	initial, err := LoadConfigWithOptions(LoadOptions{FilePath: path})
+	if err != nil {
+		t.Fatalf("initial load: %v", err)
+	}
+	initial.Storage.Repositories = map[string]RepoConfig{
+		"repo": {Tags: []string{"a"}},
+	}
+
+	mgr, err := NewManager(initial, path)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	defer func() { _ = mgr.Close() }()
+
+	snapshot := mgr.Current()
+	snapshot.Storage.Repositories["repo"] = RepoConfig{Tags: []string{"mutated"}}
+	snapshot.Storage.Repositories["other"] = RepoConfig{}
+
+	again := mgr.Current()
+	if len(again.Storage.Repositories) != 1 {
+		t.Fatalf("expected Manager's live config to still have 1 repository, got %d", len(again.Storage.Repositories))
+	}
+	if got := again.Storage.Repositories["repo"].Tags[0]; got != "a" {
+		t.Fatalf("expected mutation of one snapshot to not reach the Manager's live config, got tag %q", got)
+	}
+}
+
+func TestDiffConfigMasksSecretValuesButReportsChange(t *testing.T) {
+	oldCfg := validConfig()
+	newCfg := validConfig()
+	newCfg.OpenAI.APIKey = "rotated-key"
+
+	changes := diffConfig(oldCfg, newCfg)
+
+	var found bool
+	for _, c := range changes {
+		if c.Path != "openai.api_key" {
+			continue
+		}
+		found = true
+		if c.Old != nil || c.New != nil {
+			t.Fatalf("expected secret field's Old/New to be masked (nil), got Old=%v New=%v", c.Old, c.New)
+		}
+		if !c.RestartRequired {
+			t.Fatalf("expected restart required for openai.api_key")
+		}
+	}
+	if !found {
+		t.Fatal("expected openai.api_key change to be reported even though it's a secret field")
+	}
+}
+
+// TestDiffConfigMasksSliceElementSecretFields guards against walkConfigFieldPairs
+// treating Notifications.Sinks as one opaque leaf: before the fix, a rotated
+// NotificationSinkConfig.AuthToken surfaced in ConfigChange.Diff's New field in the
+// clear instead of being masked like every other secret field.
+func TestDiffConfigMasksSliceElementSecretFields(t *testing.T) {
+	oldCfg := validConfig()
+	oldCfg.Notifications.Sinks = []NotificationSinkConfig{
+		{Type: "webhook", URL: "https://example.com", AuthToken: "old-token"},
+	}
+	newCfg := validConfig()
+	newCfg.Notifications.Sinks = []NotificationSinkConfig{
+		{Type: "webhook", URL: "https://example.com", AuthToken: "rotated-token"},
+	}
+
+	changes := diffConfig(oldCfg, newCfg)
+
+	var found bool
+	for _, c := range changes {
+		if c.Path != "notifications.sinks[0].auth_token" {
+			continue
+		}
+		found = true
+		if c.Old != nil || c.New != nil {
+			t.Fatalf("expected secret field's Old/New to be masked (nil), got Old=%v New=%v", c.Old, c.New)
+		}
+	}
+	if !found {
+		t.Fatal("expected notifications.sinks[0].auth_token change to be reported")
+	}
+}
+
+func TestDiffConfigSkipsUnchangedFields(t *testing.T) {
+	oldCfg := validConfig()
+	newCfg := validConfig()
+
+	if changes := diffConfig(oldCfg, newCfg); len(changes) != 0 {
+		t.Fatalf("expected no changes between two identical configs, got %+v", changes)
+	}
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/LerianStudio/mcp-memory/internal/secretresolver"
+)
+
+// SecretsConfig configures the providers used to resolve secret reference URIs
+// (env://, file://, vault://, awssm://, gcpsm://) found in string fields of Config.
+type SecretsConfig struct {
+	Vault             VaultSecretsConfig      `json:"vault" yaml:"vault"`
+	AWSSecretsManager AWSSecretsManagerConfig `json:"aws_secrets_manager" yaml:"aws_secrets_manager"`
+	GCPSecretManager  GCPSecretManagerConfig  `json:"gcp_secret_manager" yaml:"gcp_secret_manager"`
+	// RefreshIntervalSeconds is how often long-running components should call
+	// Config.ResolveSecrets again to pick up rotated or short-lived secrets.
+	RefreshIntervalSeconds int `json:"refresh_interval_seconds" yaml:"refresh_interval_seconds"`
+}
+
+// VaultSecretsConfig configures access to a HashiCorp Vault cluster for vault:// URIs.
+type VaultSecretsConfig struct {
+	Address     string `json:"address" yaml:"address"`
+	Namespace   string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Token       string `json:"-" yaml:"token,omitempty"`
+	AppRoleID   string `json:"app_role_id,omitempty" yaml:"app_role_id,omitempty"`
+	AppSecretID string `json:"-" yaml:"app_secret_id,omitempty"`
+}
+
+// AWSSecretsManagerConfig configures access to AWS Secrets Manager for awssm:// URIs.
+type AWSSecretsManagerConfig struct {
+	Region string `json:"region" yaml:"region"`
+}
+
+// GCPSecretManagerConfig configures access to GCP Secret Manager for gcpsm:// URIs.
+type GCPSecretManagerConfig struct {
+	ProjectID string `json:"project_id" yaml:"project_id"`
+}
+
+// ResolveSecrets walks every string field of c and replaces any value that looks like
+// a secret reference URI (env://, file://, vault://, awssm://, gcpsm://) with the
+// value it resolves to, recording SourceSecret provenance for each one. It is called
+// automatically by LoadConfigWithOptions after environment variables are applied and
+// before Validate runs, but components holding onto a long-lived Config may call it
+// again to pick up rotated or short-lived secrets (e.g. expiring Vault leases).
+//
+// vault://, awssm://, and gcpsm:// are resolved by backends registered into
+// internal/secretresolver's registry; a binary that doesn't blank-import one of those
+// backend packages gets a "no resolver registered" error if a config actually
+// references that scheme, rather than always linking every cloud SDK.
+func (c *Config) ResolveSecrets(ctx context.Context) error {
+	root := reflect.ValueOf(c).Elem()
+
+	schemes := map[string]bool{}
+	walkConfigFields("", root, func(_ string, _ bool, value reflect.Value) {
+		if value.Kind() != reflect.String {
+			return
+		}
+		if scheme, ok := secretScheme(value.String()); ok {
+			schemes[scheme] = true
+		}
+	})
+	if len(schemes) == 0 {
+		return nil
+	}
+
+	resolvers, err := newSecretResolvers(ctx, c.Secrets, schemes)
+	if err != nil {
+		return err
+	}
+
+	if c.sources == nil {
+		c.sources = make(map[string]Source)
+	}
+
+	var resolveErr error
+	walkConfigFields("", root, func(path string, _ bool, value reflect.Value) {
+		if resolveErr != nil || value.Kind() != reflect.String {
+			return
+		}
+		scheme, ok := secretScheme(value.String())
+		if !ok {
+			return
+		}
+		resolver, ok := resolvers[scheme]
+		if !ok {
+			return
+		}
+		resolved, err := resolver.Resolve(ctx, value.String())
+		if err != nil {
+			resolveErr = fmt.Errorf("resolving secret %q: %w", value.String(), err)
+			return
+		}
+		value.SetString(resolved)
+		c.sources[path] = Source{Kind: SourceSecret, Detail: scheme}
+	})
+
+	return resolveErr
+}
+
+// schemeConfig returns the provider-specific config block to pass to
+// secretresolver.Build for scheme.
+func schemeConfig(cfg SecretsConfig, scheme string) any {
+	switch scheme {
+	case "vault":
+		return cfg.Vault
+	case "awssm":
+		return cfg.AWSSecretsManager
+	case "gcpsm":
+		return cfg.GCPSecretManager
+	default:
+		return nil
+	}
+}
+
+// newSecretResolvers builds a resolver for every scheme actually referenced in the
+// config, so that e.g. a Vault client is never constructed unless a vault:// URI is
+// present. env:// and file:// are handled locally since they need no external SDKs;
+// vault://, awssm://, and gcpsm:// are built via the secretresolver registry.
+func newSecretResolvers(ctx context.Context, cfg SecretsConfig, schemes map[string]bool) (map[string]secretresolver.Resolver, error) {
+	resolvers := map[string]secretresolver.Resolver{
+		"env":  envSecretResolver{},
+		"file": fileSecretResolver{},
+	}
+
+	for scheme := range schemes {
+		if scheme == "env" || scheme == "file" {
+			continue
+		}
+		resolver, err := secretresolver.Build(ctx, scheme, schemeConfig(cfg, scheme))
+		if err != nil {
+			return nil, fmt.Errorf("configuring %s secret resolver: %w", scheme, err)
+		}
+		resolvers[scheme] = resolver
+	}
+
+	return resolvers, nil
+}
+
+// secretScheme returns the scheme of value if it looks like a secret reference URI
+// (scheme://...), and false otherwise.
+func secretScheme(value string) (string, bool) {
+	scheme, _, ok := strings.Cut(value, "://")
+	if !ok {
+		return "", false
+	}
+	switch scheme {
+	case "env", "file", "vault", "awssm", "gcpsm":
+		return scheme, true
+	default:
+		return "", false
+	}
+}
+
+// envSecretResolver resolves env://NAME references, the same behavior LoadConfig has
+// always had for API keys loaded directly from the environment.
+type envSecretResolver struct{}
+
+func (envSecretResolver) Scheme() string { return "env" }
+
+func (envSecretResolver) Resolve(_ context.Context, uri string) (string, error) {
+	name := strings.TrimPrefix(uri, "env://")
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %q is not set", name)
+	}
+	return value, nil
+}
+
+// fileSecretResolver resolves file:///path/to/secret references by reading the file
+// contents, trimming surrounding whitespace.
+type fileSecretResolver struct{}
+
+func (fileSecretResolver) Scheme() string { return "file" }
+
+func (fileSecretResolver) Resolve(_ context.Context, uri string) (string, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("parsing file secret uri: %w", err)
+	}
+	data, err := os.ReadFile(parsed.Path) // #nosec G304 -- operator-supplied secret path
+	if err != nil {
+		return "", fmt.Errorf("reading secret file %q: %w", parsed.Path, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
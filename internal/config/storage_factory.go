@@ -0,0 +1,53 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LerianStudio/mcp-memory/internal/storage"
+
+	// Imported (for their init() registration, as well as their Options types below)
+	// unconditionally: qdrant is the default Storage.Provider and in-memory is the
+	// zero-dependency fallback used by tests and local development, so both must work
+	// out of the box without the caller having to import anything itself. Every other
+	// backend (pgvector, sqlite-vss, weaviate) pulls in a database driver or
+	// extension, so those stay opt-in — the binary that wants them imports their
+	// package itself. Neither inmemory nor qdrant imports this package back (they
+	// take their own Options type rather than the config.*Config structs below), so
+	// this isn't a cycle.
+	"github.com/LerianStudio/mcp-memory/internal/storage/inmemory"
+	"github.com/LerianStudio/mcp-memory/internal/storage/qdrant"
+)
+
+// BuildStorage constructs the active storage backend (Storage.Provider) via the
+// storage package's factory registry, so callers don't need to know which provider is
+// configured or import its package directly. The backend package (internal/storage/
+// qdrant, internal/storage/pgvector, ...) must have registered itself by the time this
+// is called, which for compiled-in backends happens automatically via its init().
+func (c *Config) BuildStorage(ctx context.Context) (storage.Storage, error) {
+	var cfg any
+
+	switch c.Storage.Provider {
+	case "qdrant":
+		cfg = qdrant.Options{
+			Host:           c.Storage.Qdrant.Host,
+			Port:           c.Storage.Qdrant.Port,
+			APIKey:         c.Storage.Qdrant.APIKey,
+			UseTLS:         c.Storage.Qdrant.UseTLS,
+			Collection:     c.Storage.Qdrant.Collection,
+			TimeoutSeconds: c.Storage.Qdrant.TimeoutSeconds,
+		}
+	case "pgvector":
+		cfg = c.Storage.PGVector
+	case "sqlite-vss":
+		cfg = c.Storage.SQLiteVSS
+	case "weaviate":
+		cfg = c.Storage.Weaviate
+	case "in-memory":
+		cfg = inmemory.Options{MaxVectors: c.Storage.InMemory.MaxVectors}
+	default:
+		return nil, fmt.Errorf("unknown storage provider: %q", c.Storage.Provider)
+	}
+
+	return storage.Build(ctx, c.Storage.Provider, cfg)
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"context"
+	"testing"
+)
+
+// TestBuildStorage_DefaultProviderIsRegistered guards against the storage factory
+// registry being empty: nothing in the binary previously called storage.Register for
+// any provider, so BuildStorage always failed with "no backend registered", even for
+// the default provider and the zero-dependency in-memory backend.
+func TestBuildStorage_DefaultProviderIsRegistered(t *testing.T) {
+	cfg := validConfig()
+
+	store, err := cfg.BuildStorage(context.Background())
+	if err != nil {
+		t.Fatalf("BuildStorage with the default provider (%q): %v", cfg.Storage.Provider, err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestBuildStorage_InMemoryProviderIsRegistered(t *testing.T) {
+	cfg := validConfig()
+	cfg.Storage.Provider = "in-memory"
+
+	store, err := cfg.BuildStorage(context.Background())
+	if err != nil {
+		t.Fatalf("BuildStorage with in-memory provider: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
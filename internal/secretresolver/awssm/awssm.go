@@ -0,0 +1,76 @@
+// Package awssm implements a secretresolver.Resolver for awssm:// secret reference
+// URIs against AWS Secrets Manager. It registers itself on import via init(), so only
+// binaries that actually import this package link aws-sdk-go-v2.
+package awssm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+
+	"github.com/LerianStudio/mcp-memory/internal/config"
+	"github.com/LerianStudio/mcp-memory/internal/secretresolver"
+)
+
+func init() {
+	secretresolver.Register("awssm", build)
+}
+
+func build(ctx context.Context, cfg any) (secretresolver.Resolver, error) {
+	awssmCfg, ok := cfg.(config.AWSSecretsManagerConfig)
+	if !ok {
+		return nil, fmt.Errorf("awssm: unexpected config type %T", cfg)
+	}
+	return newResolver(ctx, awssmCfg)
+}
+
+// resolver resolves awssm://secret-id#field references, treating the secret value as
+// a JSON object.
+type resolver struct {
+	client *secretsmanager.Client
+}
+
+func newResolver(ctx context.Context, cfg config.AWSSecretsManagerConfig) (*resolver, error) {
+	opts := []func(*awsconfig.LoadOptions) error{}
+	if cfg.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(cfg.Region))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+
+	return &resolver{client: secretsmanager.NewFromConfig(awsCfg)}, nil
+}
+
+func (r *resolver) Scheme() string { return "awssm" }
+
+func (r *resolver) Resolve(ctx context.Context, uri string) (string, error) {
+	secretID, field, err := secretresolver.SplitRef(uri, "awssm")
+	if err != nil {
+		return "", err
+	}
+
+	out, err := r.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: &secretID})
+	if err != nil {
+		return "", fmt.Errorf("reading AWS secret %q: %w", secretID, err)
+	}
+	if out.SecretString == nil {
+		return "", fmt.Errorf("AWS secret %q is stored as binary (SecretBinary), which this resolver doesn't support", secretID)
+	}
+
+	var fields map[string]string
+	if err := json.Unmarshal([]byte(*out.SecretString), &fields); err != nil {
+		return "", fmt.Errorf("AWS secret %q is not a JSON object: %w", secretID, err)
+	}
+
+	value, ok := fields[field]
+	if !ok {
+		return "", fmt.Errorf("AWS secret %q has no field %q", secretID, field)
+	}
+	return value, nil
+}
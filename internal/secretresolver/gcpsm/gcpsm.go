@@ -0,0 +1,49 @@
+// Package gcpsm implements a secretresolver.Resolver for gcpsm:// secret reference
+// URIs against GCP Secret Manager. It registers itself on import via init(), so only
+// binaries that actually import this package link cloud.google.com/go/secretmanager.
+package gcpsm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+
+	"github.com/LerianStudio/mcp-memory/internal/secretresolver"
+)
+
+func init() {
+	secretresolver.Register("gcpsm", build)
+}
+
+func build(ctx context.Context, _ any) (secretresolver.Resolver, error) {
+	return newResolver(ctx)
+}
+
+// resolver resolves gcpsm://projects/x/secrets/y/versions/z references against GCP
+// Secret Manager. Unlike vault:// and awssm://, the resource name already identifies a
+// single version, so there is no #field suffix.
+type resolver struct {
+	client *secretmanager.Client
+}
+
+func newResolver(ctx context.Context) (*resolver, error) {
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCP secret manager client: %w", err)
+	}
+	return &resolver{client: client}, nil
+}
+
+func (r *resolver) Scheme() string { return "gcpsm" }
+
+func (r *resolver) Resolve(ctx context.Context, uri string) (string, error) {
+	name := strings.TrimPrefix(uri, "gcpsm://")
+	resp, err := r.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: name})
+	if err != nil {
+		return "", fmt.Errorf("accessing GCP secret %q: %w", name, err)
+	}
+	return string(resp.Payload.Data), nil
+}
@@ -0,0 +1,79 @@
+// Package secretresolver defines the pluggable secret-reference resolver registry
+// selected by a URI scheme (vault://, awssm://, gcpsm://, ...). Concrete resolvers for
+// cloud/cluster backends live in their own sub-packages and register themselves into
+// this package's factory registry from their init() function, the same pattern
+// internal/storage uses for storage backends — so a binary that never imports, say,
+// internal/secretresolver/awssm never links the AWS SDK.
+package secretresolver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Resolver resolves a secret reference URI into its plaintext value. Each resolver
+// handles exactly one URI scheme.
+type Resolver interface {
+	// Scheme returns the URI scheme this resolver handles, e.g. "vault".
+	Scheme() string
+	// Resolve returns the plaintext value referenced by uri.
+	Resolve(ctx context.Context, uri string) (string, error)
+}
+
+// Factory constructs a Resolver for scheme from its provider-specific configuration.
+// cfg is passed as any so this package doesn't need to import the config package.
+type Factory func(ctx context.Context, cfg any) (Resolver, error)
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+// Register adds a Factory for scheme. It is meant to be called from a backend
+// package's init() function, e.g.:
+//
+//	func init() { secretresolver.Register("vault", build) }
+//
+// Register panics if scheme is already registered, since that always indicates two
+// packages claiming the same scheme.
+func Register(scheme string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, exists := factories[scheme]; exists {
+		panic(fmt.Sprintf("secretresolver: Register called twice for scheme %q", scheme))
+	}
+	factories[scheme] = factory
+}
+
+// Registered reports whether a Factory has been registered for scheme.
+func Registered(scheme string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	_, ok := factories[scheme]
+	return ok
+}
+
+// Build constructs the Resolver for scheme using its registered Factory.
+func Build(ctx context.Context, scheme string, cfg any) (Resolver, error) {
+	mu.RLock()
+	factory, ok := factories[scheme]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("secretresolver: no resolver registered for scheme %q", scheme)
+	}
+	return factory(ctx, cfg)
+}
+
+// SplitRef splits a "<scheme>://path#field" secret reference into its path and field
+// components, the convention used by backends (vault, awssm) whose secrets are
+// multi-field documents rather than a single plaintext value.
+func SplitRef(uri, scheme string) (path, field string, err error) {
+	rest := strings.TrimPrefix(uri, scheme+"://")
+	path, field, ok := strings.Cut(rest, "#")
+	if !ok {
+		return "", "", fmt.Errorf("%s secret uri %q must be of the form %s://path#field", scheme, uri, scheme)
+	}
+	return path, field, nil
+}
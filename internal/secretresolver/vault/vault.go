@@ -0,0 +1,101 @@
+// Package vault implements a secretresolver.Resolver for vault:// secret reference
+// URIs against a HashiCorp Vault cluster. It registers itself on import via init(), so
+// only binaries that actually import this package link hashicorp/vault/api.
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	vaultapi "github.com/hashicorp/vault/api"
+
+	"github.com/LerianStudio/mcp-memory/internal/config"
+	"github.com/LerianStudio/mcp-memory/internal/secretresolver"
+)
+
+func init() {
+	secretresolver.Register("vault", build)
+}
+
+func build(_ context.Context, cfg any) (secretresolver.Resolver, error) {
+	vaultCfg, ok := cfg.(config.VaultSecretsConfig)
+	if !ok {
+		return nil, fmt.Errorf("vault: unexpected config type %T", cfg)
+	}
+	return newResolver(vaultCfg)
+}
+
+// resolver resolves vault://path#field references, authenticating via token or
+// AppRole.
+type resolver struct {
+	client *vaultapi.Client
+}
+
+func newResolver(cfg config.VaultSecretsConfig) (*resolver, error) {
+	vc := vaultapi.DefaultConfig()
+	if cfg.Address != "" {
+		vc.Address = cfg.Address
+	}
+
+	client, err := vaultapi.NewClient(vc)
+	if err != nil {
+		return nil, fmt.Errorf("creating vault client: %w", err)
+	}
+	if cfg.Namespace != "" {
+		client.SetNamespace(cfg.Namespace)
+	}
+
+	switch {
+	case cfg.Token != "":
+		client.SetToken(cfg.Token)
+	case cfg.AppRoleID != "" && cfg.AppSecretID != "":
+		if err := appRoleLogin(client, cfg.AppRoleID, cfg.AppSecretID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &resolver{client: client}, nil
+}
+
+func appRoleLogin(client *vaultapi.Client, roleID, secretID string) error {
+	resp, err := client.Logical().Write("auth/approle/login", map[string]interface{}{
+		"role_id":   roleID,
+		"secret_id": secretID,
+	})
+	if err != nil {
+		return fmt.Errorf("vault approle login: %w", err)
+	}
+	if resp == nil || resp.Auth == nil {
+		return errors.New("vault approle login returned no auth info")
+	}
+	client.SetToken(resp.Auth.ClientToken)
+	return nil
+}
+
+func (r *resolver) Scheme() string { return "vault" }
+
+func (r *resolver) Resolve(ctx context.Context, uri string) (string, error) {
+	path, field, err := secretresolver.SplitRef(uri, "vault")
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := r.client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return "", fmt.Errorf("reading vault secret %q: %w", path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return "", fmt.Errorf("vault secret %q not found", path)
+	}
+
+	value, ok := secret.Data[field]
+	if !ok {
+		return "", fmt.Errorf("vault secret %q has no field %q", path, field)
+	}
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("vault secret %q field %q is not a string", path, field)
+	}
+	return str, nil
+}
@@ -0,0 +1,57 @@
+// Package inmemory implements a storage.Storage backend that keeps vectors in a plain
+// in-process map, for tests and local development where persistence isn't needed. It
+// has no external dependencies, so internal/config/storage_factory.go imports it
+// unconditionally rather than requiring callers to opt in the way they do for the other
+// backends. It takes its own Options type rather than internal/config's InMemoryConfig
+// so it can be imported by the config package without an import cycle.
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/LerianStudio/mcp-memory/internal/storage"
+)
+
+func init() {
+	storage.Register("in-memory", build)
+}
+
+// Options configures a Store.
+type Options struct {
+	MaxVectors int
+}
+
+func build(_ context.Context, cfg any) (storage.Storage, error) {
+	opts, ok := cfg.(Options)
+	if !ok {
+		return nil, fmt.Errorf("inmemory: unexpected config type %T", cfg)
+	}
+	return New(opts), nil
+}
+
+// Store is a trivial in-process storage.Storage backend: vectors live in a map for the
+// lifetime of the process and are discarded on Close.
+type Store struct {
+	maxVectors int
+
+	mu      sync.Mutex
+	vectors map[string][]float32
+}
+
+// New creates a Store configured by opts.
+func New(opts Options) *Store {
+	return &Store{
+		maxVectors: opts.MaxVectors,
+		vectors:    make(map[string][]float32),
+	}
+}
+
+// Close discards every stored vector.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vectors = nil
+	return nil
+}
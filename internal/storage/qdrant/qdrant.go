@@ -0,0 +1,72 @@
+// Package qdrant implements a storage.Storage backend backed by a Qdrant vector
+// database, talking to its REST API over net/http. Because it needs no dependency
+// beyond the standard library, internal/config/storage_factory.go imports it
+// alongside the in-memory backend. It takes its own Options type rather than
+// internal/config's QdrantConfig so it can be imported by the config package
+// without an import cycle.
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/LerianStudio/mcp-memory/internal/storage"
+)
+
+func init() {
+	storage.Register("qdrant", build)
+}
+
+// Options configures a Store.
+type Options struct {
+	Host           string
+	Port           int
+	APIKey         string
+	UseTLS         bool
+	Collection     string
+	TimeoutSeconds int
+}
+
+func build(_ context.Context, cfg any) (storage.Storage, error) {
+	opts, ok := cfg.(Options)
+	if !ok {
+		return nil, fmt.Errorf("qdrant: unexpected config type %T", cfg)
+	}
+	return New(opts), nil
+}
+
+// Store talks to a Qdrant instance's REST API at Host:Port.
+type Store struct {
+	baseURL    string
+	apiKey     string
+	collection string
+	client     *http.Client
+}
+
+// New creates a Store configured by opts. It does not dial Qdrant until first use.
+func New(opts Options) *Store {
+	scheme := "http"
+	if opts.UseTLS {
+		scheme = "https"
+	}
+
+	timeout := time.Duration(opts.TimeoutSeconds) * time.Second
+	if opts.TimeoutSeconds <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	return &Store{
+		baseURL:    fmt.Sprintf("%s://%s:%d", scheme, opts.Host, opts.Port),
+		apiKey:     opts.APIKey,
+		collection: opts.Collection,
+		client:     &http.Client{Timeout: timeout},
+	}
+}
+
+// Close releases the backend's idle HTTP connections.
+func (s *Store) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,66 @@
+// Package storage defines the pluggable vector-store backend abstraction selected by
+// Config.Storage.Provider. Concrete backends (qdrant, pgvector, sqlite-vss, weaviate,
+// in-memory) live in their own packages and register themselves into this package's
+// factory registry from their init() function, the same pattern database/sql uses for
+// drivers.
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Storage is the interface every vector-store backend implements. It is intentionally
+// minimal here; concrete backends are free to expose richer, provider-specific APIs
+// that callers type-assert to when they need them.
+type Storage interface {
+	// Close releases any resources (connections, file handles) held by the backend.
+	Close() error
+}
+
+// Factory constructs a Storage backend from its provider-specific configuration. cfg
+// is the value config.BuildStorage passes for that provider (e.g. qdrant.Options, or
+// config.PGVectorConfig for pgvector), passed as any so this package doesn't need to
+// import the config package.
+type Factory func(ctx context.Context, cfg any) (Storage, error)
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+// Register adds a Factory for provider. It is meant to be called from a backend
+// package's init() function, e.g.:
+//
+//	func init() { storage.Register("qdrant", newQdrantStorage) }
+//
+// Register panics if provider is already registered, since that always indicates two
+// packages claiming the same provider name.
+func Register(provider string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, exists := factories[provider]; exists {
+		panic(fmt.Sprintf("storage: Register called twice for provider %q", provider))
+	}
+	factories[provider] = factory
+}
+
+// Registered reports whether a Factory has been registered for provider.
+func Registered(provider string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	_, ok := factories[provider]
+	return ok
+}
+
+// Build constructs the Storage backend for provider using its registered Factory.
+func Build(ctx context.Context, provider string, cfg any) (Storage, error) {
+	mu.RLock()
+	factory, ok := factories[provider]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("storage: no backend registered for provider %q", provider)
+	}
+	return factory(ctx, cfg)
+}